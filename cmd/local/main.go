@@ -0,0 +1,112 @@
+// Command local serves the document, questionnaire and result APIs from one
+// net/http server for offline development. Combine it with a local Postgres
+// and STORAGE_BACKEND=local (or memory) to run without AWS:
+//
+//	DATABASE_URL=postgres://... STORAGE_BACKEND=local AUTH_TRUST_HEADERS=true go run ./cmd/local
+//
+// Requests carry no authorizer context, so with AUTH_TRUST_HEADERS=true the
+// caller is taken from the X-Actor-Id, X-Actor-Role and X-Tenant-Id headers.
+// Files of the local and memory backends are served under LOCAL_STORAGE_URL.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/httpadapter"
+	"security-questionnaire/pkg/middleware"
+	"security-questionnaire/pkg/router"
+	"security-questionnaire/pkg/storage"
+	"security-questionnaire/services/monolith"
+)
+
+// shutdownTimeout bounds how long in-flight requests may finish on exit
+const shutdownTimeout = 10 * time.Second
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	// Handlers create their own storage backend per request, so they must
+	// all sign URLs with the same key as the file server below
+	if os.Getenv("STORAGE_SIGNING_KEY") == "" {
+		key := make([]byte, 32)
+		_, _ = rand.Read(key)
+		os.Setenv("STORAGE_SIGNING_KEY", hex.EncodeToString(key))
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
+
+	r := router.New()
+	r.Use(middleware.Standard(cfg)...)
+	monolith.Register(r)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", httpadapter.Handler(r.Serve))
+	if err := mountStorage(mux, cfg); err != nil {
+		log.Fatalf("Failed to serve storage: %v", err)
+	}
+
+	server := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("Listening on %s", *addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Shutdown failed: %v", err)
+	}
+}
+
+// mountStorage serves the signed URLs of the local and memory backends at
+// the path of LOCAL_STORAGE_URL. S3 URLs point at AWS and need no server.
+func mountStorage(mux *http.ServeMux, cfg *config.Config) error {
+	if cfg.StorageBackend != storage.BackendLocal && cfg.StorageBackend != storage.BackendMemory {
+		return nil
+	}
+
+	store, err := storage.New(cfg)
+	if err != nil {
+		return err
+	}
+	signed, ok := store.(interface{ Signer() *storage.URLSigner })
+	if !ok {
+		return nil
+	}
+
+	base, err := url.Parse(cfg.LocalStorageURL)
+	if err != nil {
+		return err
+	}
+	prefix := strings.TrimSuffix(base.Path, "/")
+	if prefix == "" {
+		return errors.New("LOCAL_STORAGE_URL must include a path, e.g. http://localhost:8080/storage")
+	}
+
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, storage.NewSignedURLHandler(store, signed.Signer())))
+	log.Printf("Serving %s storage at %s", cfg.StorageBackend, cfg.LocalStorageURL)
+	return nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the application
@@ -10,12 +13,48 @@ type Config struct {
 	// Database configuration
 	DatabaseURL string
 
+	// Database connection pool configuration
+	DBMaxOpenConns        int
+	DBMaxIdleConns        int
+	DBConnMaxLifetime     time.Duration
+	DBConnMaxIdleTime     time.Duration
+	DBHealthCheckInterval time.Duration
+
 	// AWS S3 configuration
 	S3Bucket string
 	S3Region string
 
+	// Storage backend configuration (s3, local or memory)
+	StorageBackend    string
+	LocalStorageDir   string
+	LocalStorageURL   string
+	StorageSigningKey string
+
 	// AWS configuration
 	AWSRegion string
+
+	// Request deadlines: handlers stop RequestTimeoutMargin before the Lambda
+	// deadline, or after RequestTimeout when the invocation has no deadline
+	RequestTimeoutMargin time.Duration
+	RequestTimeout       time.Duration
+
+	// CORS configuration
+	CORSAllowedOrigins []string
+	CORSAllowedHeaders []string
+	CORSMaxAge         time.Duration
+
+	// Caller identity: the JWT claim holding the caller's role, the roles of
+	// IAM principals by role or user name, and whether X-Actor-Role and
+	// X-Actor-Id headers identify callers without an authorizer (local only)
+	AuthRoleClaim    string
+	AuthIAMRoles     map[string]string
+	AuthTrustHeaders bool
+
+	// Caller tenant: the JWT claim holding it, the tenants of IAM principals
+	// by role or user name, and the tenant of callers that have none
+	AuthTenantClaim   string
+	AuthIAMTenants    map[string]string
+	AuthDefaultTenant string
 }
 
 // LoadConfig loads configuration from environment variables
@@ -25,15 +64,65 @@ func LoadConfig() (*Config, error) {
 		S3Bucket:    os.Getenv("S3_BUCKET"),
 		S3Region:    getEnvOrDefault("S3_REGION", "us-east-1"),
 		AWSRegion:   getEnvOrDefault("AWS_REGION", "us-east-1"),
+
+		StorageBackend:    getEnvOrDefault("STORAGE_BACKEND", "s3"),
+		LocalStorageDir:   getEnvOrDefault("LOCAL_STORAGE_DIR", "./data/storage"),
+		LocalStorageURL:   getEnvOrDefault("LOCAL_STORAGE_URL", "http://localhost:8080/storage"),
+		StorageSigningKey: os.Getenv("STORAGE_SIGNING_KEY"),
+
+		CORSAllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS", "*"),
+		CORSAllowedHeaders: getEnvList("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,X-Amz-Date,X-Amz-Security-Token,X-Actor-Role,X-Actor-Id,X-Tenant-Id"),
+
+		AuthRoleClaim:    getEnvOrDefault("AUTH_ROLE_CLAIM", "custom:role"),
+		AuthTrustHeaders: os.Getenv("AUTH_TRUST_HEADERS") == "true",
+
+		AuthTenantClaim:   getEnvOrDefault("AUTH_TENANT_CLAIM", "custom:tenant_id"),
+		AuthDefaultTenant: os.Getenv("AUTH_DEFAULT_TENANT"),
 	}
 
 	// Validate required configurations
 	if cfg.DatabaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL environment variable is required")
 	}
+	// URLs signed by one process must verify on every other one
+	if (cfg.StorageBackend == "local" || cfg.StorageBackend == "memory") && cfg.StorageSigningKey == "" {
+		return nil, fmt.Errorf("STORAGE_SIGNING_KEY environment variable is required for the %s storage backend", cfg.StorageBackend)
+	}
 
-	if cfg.S3Bucket == "" {
-		return nil, fmt.Errorf("S3_BUCKET environment variable is required")
+	// Lambda runs one request at a time per instance, so keep the pool small
+	// to stay within the connection limits of the Supabase pooler
+	var err error
+	if cfg.DBMaxOpenConns, err = getEnvInt("DB_MAX_OPEN_CONNS", 2); err != nil {
+		return nil, err
+	}
+	if cfg.DBMaxIdleConns, err = getEnvInt("DB_MAX_IDLE_CONNS", 2); err != nil {
+		return nil, err
+	}
+	if cfg.DBConnMaxLifetime, err = getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.DBConnMaxIdleTime, err = getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.DBHealthCheckInterval, err = getEnvDuration("DB_HEALTH_CHECK_INTERVAL", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	// API Gateway gives up on an integration after 30 seconds
+	if cfg.RequestTimeoutMargin, err = getEnvDuration("REQUEST_TIMEOUT_MARGIN", 500*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if cfg.RequestTimeout, err = getEnvDuration("REQUEST_TIMEOUT", 29*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.CORSMaxAge, err = getEnvDuration("CORS_MAX_AGE", 50*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.AuthIAMRoles, err = getEnvMap("AUTH_IAM_ROLES"); err != nil {
+		return nil, err
+	}
+	if cfg.AuthIAMTenants, err = getEnvMap("AUTH_IAM_TENANTS"); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
@@ -46,3 +135,55 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList gets a comma-separated environment variable or returns the default list
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnvOrDefault(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvMap gets a comma-separated list of key=value pairs, e.g.
+// "questionnaire-admin=admin,questionnaire-auditor=auditor"
+func getEnvMap(key string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range getEnvList(key, "") {
+		k, v, ok := strings.Cut(pair, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("%s must be a comma-separated list of name=value pairs", key)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// getEnvInt gets a non-negative integer environment variable or returns default value
+func getEnvInt(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", key)
+	}
+	return n, nil
+}
+
+// getEnvDuration gets a duration environment variable (e.g. "30s", "5m") or returns default value
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration such as \"30s\" or \"5m\"", key)
+	}
+	return d, nil
+}
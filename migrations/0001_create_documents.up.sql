@@ -0,0 +1,38 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS documents (
+    id           uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    deleted_at   timestamptz,
+    file_name    text NOT NULL,
+    file_size    bigint NOT NULL,
+    content_type text NOT NULL,
+    s3_bucket    text NOT NULL,
+    s3_key       text NOT NULL,
+    s3_url       text NOT NULL,
+    description  text,
+    tags         text,
+    status       text NOT NULL DEFAULT 'active',
+    upload_id    text
+);
+
+-- Databases created by gorm AutoMigrate before migrations existed already have
+-- this table, so CREATE TABLE IF NOT EXISTS skips it: align its columns with
+-- the definition above. A fresh database is left unchanged.
+ALTER TABLE documents
+    ALTER COLUMN id TYPE uuid USING id::uuid,
+    ALTER COLUMN id SET DEFAULT uuid_generate_v4(),
+    ALTER COLUMN file_name SET NOT NULL,
+    ALTER COLUMN file_size TYPE bigint,
+    ALTER COLUMN file_size SET NOT NULL,
+    ALTER COLUMN content_type SET NOT NULL,
+    ALTER COLUMN s3_bucket SET NOT NULL,
+    ALTER COLUMN s3_key SET NOT NULL,
+    ALTER COLUMN s3_url SET NOT NULL,
+    ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS upload_id text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_documents_s3_key ON documents (s3_key);
+CREATE INDEX IF NOT EXISTS idx_documents_status ON documents (status);
+CREATE INDEX IF NOT EXISTS idx_documents_deleted_at ON documents (deleted_at);
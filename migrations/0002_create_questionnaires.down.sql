@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS questionnaire_versions;
+DROP TABLE IF EXISTS questionnaires;
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS questionnaires (
+    id             uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    deleted_at     timestamptz,
+    name           text NOT NULL,
+    description    text,
+    sections       jsonb,
+    latest_version bigint NOT NULL DEFAULT 0
+);
+
+-- Databases created by gorm AutoMigrate before migrations existed already have
+-- this table, so CREATE TABLE IF NOT EXISTS skips it: align its columns with
+-- the definition above. A fresh database is left unchanged.
+ALTER TABLE questionnaires
+    ALTER COLUMN id TYPE uuid USING id::uuid,
+    ALTER COLUMN id SET DEFAULT uuid_generate_v4(),
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN sections TYPE jsonb USING sections::jsonb,
+    ADD COLUMN IF NOT EXISTS latest_version bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_questionnaires_deleted_at ON questionnaires (deleted_at);
+
+CREATE TABLE IF NOT EXISTS questionnaire_versions (
+    id               uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at       timestamptz,
+    updated_at       timestamptz,
+    deleted_at       timestamptz,
+    questionnaire_id uuid NOT NULL,
+    version          bigint NOT NULL,
+    name             text NOT NULL,
+    description      text,
+    sections         jsonb NOT NULL,
+    published_at     timestamptz NOT NULL
+);
+
+ALTER TABLE questionnaire_versions
+    ALTER COLUMN id TYPE uuid USING id::uuid,
+    ALTER COLUMN id SET DEFAULT uuid_generate_v4(),
+    ALTER COLUMN questionnaire_id TYPE uuid USING questionnaire_id::uuid,
+    ALTER COLUMN questionnaire_id SET NOT NULL,
+    ALTER COLUMN version TYPE bigint,
+    ALTER COLUMN version SET NOT NULL,
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN sections TYPE jsonb USING sections::jsonb,
+    ALTER COLUMN sections SET NOT NULL,
+    ALTER COLUMN published_at SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_questionnaire_version ON questionnaire_versions (questionnaire_id, version);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_versions_deleted_at ON questionnaire_versions (deleted_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS result_evidence;
+DROP TABLE IF EXISTS results;
@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS results (
+    id                    uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at            timestamptz,
+    updated_at            timestamptz,
+    deleted_at            timestamptz,
+    questionnaire_id      text NOT NULL,
+    questionnaire_version bigint NOT NULL DEFAULT 1,
+    data                  jsonb,
+    status                text NOT NULL DEFAULT 'draft',
+    status_history        jsonb,
+    score                 bigint,
+    section_scores        jsonb,
+    completed_at          bigint
+);
+
+-- Databases created by gorm AutoMigrate before migrations existed already have
+-- this table, so CREATE TABLE IF NOT EXISTS skips it: align its columns with
+-- the definition above. A fresh database is left unchanged.
+ALTER TABLE results
+    ALTER COLUMN id TYPE uuid USING id::uuid,
+    ALTER COLUMN id SET DEFAULT uuid_generate_v4(),
+    ALTER COLUMN questionnaire_id TYPE text,
+    ALTER COLUMN questionnaire_id SET NOT NULL,
+    ALTER COLUMN data TYPE jsonb USING data::jsonb,
+    ALTER COLUMN status SET DEFAULT 'draft',
+    ALTER COLUMN status SET NOT NULL,
+    ADD COLUMN IF NOT EXISTS questionnaire_version bigint NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS status_history jsonb,
+    ADD COLUMN IF NOT EXISTS section_scores jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_results_questionnaire_id ON results (questionnaire_id);
+CREATE INDEX IF NOT EXISTS idx_results_deleted_at ON results (deleted_at);
+
+CREATE TABLE IF NOT EXISTS result_evidence (
+    id           uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    deleted_at   timestamptz,
+    result_id    uuid NOT NULL,
+    question_key text NOT NULL,
+    document_id  uuid NOT NULL
+);
+
+ALTER TABLE result_evidence
+    ALTER COLUMN id TYPE uuid USING id::uuid,
+    ALTER COLUMN id SET DEFAULT uuid_generate_v4(),
+    ALTER COLUMN result_id TYPE uuid USING result_id::uuid,
+    ALTER COLUMN result_id SET NOT NULL,
+    ALTER COLUMN question_key SET NOT NULL,
+    ALTER COLUMN document_id TYPE uuid USING document_id::uuid,
+    ALTER COLUMN document_id SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_result_evidence ON result_evidence (result_id, question_key, document_id);
+CREATE INDEX IF NOT EXISTS idx_result_evidence_document_id ON result_evidence (document_id);
+CREATE INDEX IF NOT EXISTS idx_result_evidence_deleted_at ON result_evidence (deleted_at);
@@ -0,0 +1,2 @@
+-- Recomputing scores selects the results of one questionnaire version
+CREATE INDEX IF NOT EXISTS idx_results_questionnaire_version ON results (questionnaire_id, questionnaire_version);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_results_created_at_id;
+DROP INDEX IF EXISTS idx_documents_created_at_id;
@@ -0,0 +1,3 @@
+-- Keyset pagination walks documents and results in (created_at, id) order
+CREATE INDEX IF NOT EXISTS idx_documents_created_at_id ON documents (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_results_created_at_id ON results (created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_results_questionnaire_id_id;
+DROP INDEX IF EXISTS idx_documents_content_type_id;
+DROP INDEX IF EXISTS idx_documents_file_size_id;
+DROP INDEX IF EXISTS idx_documents_file_name_id;
@@ -0,0 +1,5 @@
+-- Sortable list columns, paired with id for keyset pagination
+CREATE INDEX IF NOT EXISTS idx_documents_file_name_id ON documents (file_name, id);
+CREATE INDEX IF NOT EXISTS idx_documents_file_size_id ON documents (file_size, id);
+CREATE INDEX IF NOT EXISTS idx_documents_content_type_id ON documents (content_type, id);
+CREATE INDEX IF NOT EXISTS idx_results_questionnaire_id_id ON results (questionnaire_id, id);
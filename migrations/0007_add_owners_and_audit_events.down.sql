@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS audit_events;
+
+DROP INDEX IF EXISTS idx_results_owner_id;
+DROP INDEX IF EXISTS idx_documents_owner_id;
+
+ALTER TABLE results DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE documents DROP COLUMN IF EXISTS owner_id;
@@ -0,0 +1,26 @@
+-- Owners of documents and results, so vendors only see their own records.
+-- Existing rows have no owner and are visible to every other role.
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS owner_id text;
+ALTER TABLE results ADD COLUMN IF NOT EXISTS owner_id text;
+
+CREATE INDEX IF NOT EXISTS idx_documents_owner_id ON documents (owner_id);
+CREATE INDEX IF NOT EXISTS idx_results_owner_id ON results (owner_id);
+
+-- Append-only record of denied and unauthenticated requests
+CREATE TABLE IF NOT EXISTS audit_events (
+    id            uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    request_id    text,
+    subject       text,
+    role          text,
+    source        text,
+    action        text,
+    resource_type text,
+    resource_id   text,
+    outcome       text NOT NULL,
+    reason        text,
+    source_ip     text
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_subject ON audit_events (subject, created_at);
@@ -0,0 +1,21 @@
+ALTER TABLE audit_events DROP COLUMN IF EXISTS tenant_id;
+
+UPDATE documents
+SET s3_url = replace(s3_url, '/' || s3_key, '/' || substr(s3_key, length('tenants/default/') + 1)),
+    s3_key = substr(s3_key, length('tenants/default/') + 1)
+WHERE s3_key LIKE 'tenants/default/%';
+
+DROP INDEX IF EXISTS idx_results_tenant_created_at_id;
+DROP INDEX IF EXISTS idx_documents_tenant_created_at_id;
+
+DROP INDEX IF EXISTS idx_result_evidence_tenant_id;
+DROP INDEX IF EXISTS idx_results_tenant_id;
+DROP INDEX IF EXISTS idx_questionnaire_versions_tenant_id;
+DROP INDEX IF EXISTS idx_questionnaires_tenant_id;
+DROP INDEX IF EXISTS idx_documents_tenant_id;
+
+ALTER TABLE result_evidence DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE results DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE questionnaire_versions DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE questionnaires DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE documents DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,32 @@
+-- Every record belongs to a tenant. Existing records move to the "default"
+-- tenant; new records get the tenant of the caller that creates them.
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE questionnaires ADD COLUMN IF NOT EXISTS tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE questionnaire_versions ADD COLUMN IF NOT EXISTS tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE results ADD COLUMN IF NOT EXISTS tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE result_evidence ADD COLUMN IF NOT EXISTS tenant_id text NOT NULL DEFAULT 'default';
+
+ALTER TABLE documents ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE questionnaires ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE questionnaire_versions ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE results ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE result_evidence ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_documents_tenant_id ON documents (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaires_tenant_id ON questionnaires (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_questionnaire_versions_tenant_id ON questionnaire_versions (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_results_tenant_id ON results (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_result_evidence_tenant_id ON result_evidence (tenant_id);
+
+-- Lists are always filtered by tenant, so keyset pagination walks each tenant's records
+CREATE INDEX IF NOT EXISTS idx_documents_tenant_created_at_id ON documents (tenant_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_results_tenant_created_at_id ON results (tenant_id, created_at, id);
+
+-- Files live under tenants/<tenant_id>/. The objects of existing documents
+-- must be moved there as well, see "Tenants" in README.md.
+UPDATE documents
+SET s3_key = 'tenants/default/' || s3_key,
+    s3_url = replace(s3_url, '/' || s3_key, '/tenants/default/' || s3_key)
+WHERE s3_key NOT LIKE 'tenants/%';
+
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS tenant_id text;
@@ -0,0 +1,3 @@
+-- Legacy "pending" results cannot be told apart from drafts any more; they
+-- stay "draft", which the service treats the same way.
+SELECT 1;
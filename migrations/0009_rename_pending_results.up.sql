@@ -0,0 +1,5 @@
+-- "pending" is the status results had before the lifecycle was introduced and
+-- behaves like "draft". Rewrite it so status filters match those results too.
+UPDATE results SET status = 'draft' WHERE status = 'pending';
+
+ALTER TABLE results ALTER COLUMN status SET DEFAULT 'draft';
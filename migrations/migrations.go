@@ -0,0 +1,15 @@
+// Package migrations embeds the versioned SQL migrations of the database schema.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql and
+// applied in version order by pkg/migrate. Tables are created with IF NOT
+// EXISTS and their columns then aligned with ALTER TABLE, so databases
+// previously managed by gorm AutoMigrate are brought in line with the schema.
+// Never edit a migration that has been deployed; add a new one instead.
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
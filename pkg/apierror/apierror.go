@@ -0,0 +1,145 @@
+// Package apierror maps errors from the shared libraries to HTTP responses,
+// so every service reports the same status code for the same failure.
+package apierror
+
+import (
+	"context"
+	"errors"
+
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/query"
+	"security-questionnaire/pkg/storage"
+)
+
+// Error codes shared by all services. Clients branch on the code, never on
+// the message, so a code must not change once released.
+const (
+	CodeBadRequest          = "bad_request"
+	CodeInvalidBody         = "invalid_body"
+	CodeInvalidParameter    = "invalid_parameter"
+	CodeInvalidCursor       = "invalid_cursor"
+	CodeValidationFailed    = "validation_failed"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeRouteNotFound       = "route_not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeConflict            = "conflict"
+	CodeAlreadyExists       = "already_exists"
+	CodeReferenceConflict   = "reference_conflict"
+	CodeConcurrentUpdate    = "concurrent_update"
+	CodePayloadTooLarge     = "payload_too_large"
+	CodeRangeNotSatisfiable = "range_not_satisfiable"
+	CodeUnprocessable       = "unprocessable"
+	CodeInternal            = "internal_error"
+	CodeNotImplemented      = "not_implemented"
+	CodeUnavailable         = "unavailable"
+	CodeDatabaseUnavailable = "database_unavailable"
+	CodeTimeout             = "timeout"
+	CodeDatabaseTimeout     = "database_timeout"
+	CodeStorageTimeout      = "storage_timeout"
+)
+
+// Field error codes describing why a single field was rejected
+const (
+	FieldRequired   = "required"
+	FieldInvalid    = "invalid"
+	FieldOutOfRange = "out_of_range"
+)
+
+// CodeForStatus returns the generic code for a status code, used when a
+// response has no more specific one
+func CodeForStatus(status int) string {
+	switch status {
+	case 400:
+		return CodeBadRequest
+	case 401:
+		return CodeUnauthorized
+	case 403:
+		return CodeForbidden
+	case 404:
+		return CodeNotFound
+	case 405:
+		return CodeMethodNotAllowed
+	case 409:
+		return CodeConflict
+	case 413:
+		return CodePayloadTooLarge
+	case 416:
+		return CodeRangeNotSatisfiable
+	case 422:
+		return CodeUnprocessable
+	case 501:
+		return CodeNotImplemented
+	case 503:
+		return CodeUnavailable
+	case 504:
+		return CodeTimeout
+	default:
+		if status < 500 {
+			return CodeBadRequest
+		}
+		return CodeInternal
+	}
+}
+
+// Status returns the HTTP status code for err:
+// 400 for invalid query parameters and cursors, 403 for files of another tenant, 404 for missing records and files,
+// 409 for constraint violations, 503 for failures worth retrying, 504 for timeouts and 500 for everything else.
+func Status(err error) int {
+	var queryErr *query.Error
+	switch {
+	case errors.As(err, &queryErr), errors.Is(err, database.ErrInvalidCursor):
+		return 400
+	case errors.Is(err, storage.ErrOtherTenant):
+		return 403
+	case errors.Is(err, database.ErrNotFound), errors.Is(err, storage.ErrNotFound):
+		return 404
+	case errors.Is(err, database.ErrUniqueViolation), errors.Is(err, database.ErrForeignKeyViolation):
+		return 409
+	case errors.Is(err, database.ErrTimeout),
+		errors.Is(err, storage.ErrTimeout),
+		errors.Is(err, context.DeadlineExceeded):
+		return 504
+	case errors.Is(err, database.ErrSerializationFailure),
+		errors.Is(err, database.ErrUnavailable):
+		return 503
+	default:
+		return 500
+	}
+}
+
+// Describe returns the status code, error code and client message for err.
+// resource names what was being accessed (e.g. "Document") and action
+// describes the operation (e.g. "Failed to update document"); the action is
+// the message of unexpected errors, whose details must only be logged.
+func Describe(err error, resource, action string) (int, string, string) {
+	status := Status(err)
+	var queryErr *query.Error
+	switch {
+	case errors.As(err, &queryErr):
+		return status, CodeInvalidParameter, queryErr.Error()
+	case errors.Is(err, database.ErrInvalidCursor):
+		return status, CodeInvalidCursor, "Invalid cursor; start again from the first page"
+	case status == 403:
+		return status, CodeForbidden, "You do not have permission to perform this action"
+	case status == 404:
+		return status, CodeNotFound, resource + " not found"
+	case errors.Is(err, database.ErrUniqueViolation):
+		return status, CodeAlreadyExists, resource + " already exists"
+	case errors.Is(err, database.ErrForeignKeyViolation):
+		return status, CodeReferenceConflict, resource + " references or is referenced by another record"
+	case errors.Is(err, database.ErrSerializationFailure):
+		return status, CodeConcurrentUpdate, "The request conflicted with a concurrent update, please retry"
+	case errors.Is(err, database.ErrTimeout):
+		return status, CodeDatabaseTimeout, "The database did not respond in time, please retry"
+	case errors.Is(err, storage.ErrTimeout):
+		return status, CodeStorageTimeout, "File storage did not respond in time, please retry"
+	case status == 504:
+		return status, CodeTimeout, "The request timed out, please retry"
+	case errors.Is(err, database.ErrUnavailable):
+		return status, CodeDatabaseUnavailable, "The database is temporarily unavailable, please retry"
+	default:
+		return status, CodeInternal, action
+	}
+}
@@ -0,0 +1,33 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/query"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "unknown parameter", err: &query.Error{Param: "owner_id", Message: "unknown parameter"}, wantStatus: 400, wantCode: CodeInvalidParameter},
+		{name: "invalid cursor", err: fmt.Errorf("%w: it was issued for another sort order", database.ErrInvalidCursor), wantStatus: 400, wantCode: CodeInvalidCursor},
+		{name: "not found", err: fmt.Errorf("failed to get record: %w", database.ErrNotFound), wantStatus: 404, wantCode: CodeNotFound},
+		{name: "unexpected", err: errors.New("boom"), wantStatus: 500, wantCode: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code, _ := Describe(tt.err, "Document", "Failed to list documents")
+			if status != tt.wantStatus || code != tt.wantCode {
+				t.Fatalf("Describe(%v) = %d %s, want %d %s", tt.err, status, code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
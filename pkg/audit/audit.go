@@ -0,0 +1,89 @@
+// Package audit records security-relevant events, such as denied requests,
+// in the audit_events table. Events are append-only: they are never updated
+// or deleted by the services.
+package audit
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+)
+
+// Outcomes of audited requests
+const (
+	OutcomeDenied          = "denied"
+	OutcomeUnauthenticated = "unauthenticated"
+)
+
+// Event is a single audit record
+type Event struct {
+	ID        string    `gorm:"column:id;primaryKey;type:uuid;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	RequestID string    `gorm:"column:request_id" json:"request_id"`
+	// Subject, Role and TenantID identify the caller, Source how it was authenticated
+	Subject  string `gorm:"column:subject" json:"subject"`
+	Role     string `gorm:"column:role" json:"role"`
+	TenantID string `gorm:"column:tenant_id" json:"tenant_id,omitempty"`
+	Source   string `gorm:"column:source" json:"source"`
+	// Action is the route name, e.g. "results.update"
+	Action       string `gorm:"column:action" json:"action"`
+	ResourceType string `gorm:"column:resource_type" json:"resource_type,omitempty"`
+	ResourceID   string `gorm:"column:resource_id" json:"resource_id,omitempty"`
+	Outcome      string `gorm:"column:outcome;not null" json:"outcome"`
+	Reason       string `gorm:"column:reason" json:"reason"`
+	SourceIP     string `gorm:"column:source_ip" json:"source_ip,omitempty"`
+}
+
+// TableName specifies the table name for the Event model
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// ErrNoRecorder is returned when an event is recorded without a Recorder in the context
+var ErrNoRecorder = errors.New("no audit recorder configured")
+
+// Recorder stores events in the database of the configuration it was
+// created with, through the shared connection pool the handlers use
+type Recorder struct {
+	cfg *config.Config
+}
+
+// NewRecorder creates a recorder writing to the database of cfg
+func NewRecorder(cfg *config.Config) *Recorder {
+	return &Recorder{cfg: cfg}
+}
+
+// Record stores event. Every event is logged as well, so it is not lost
+// when the database cannot be reached; the error is returned for callers
+// that must not proceed without an audit trail. A nil recorder only logs.
+func (r *Recorder) Record(ctx context.Context, event Event) error {
+	log.Printf("audit request_id=%s outcome=%s subject=%q role=%s tenant=%q action=%s resource_type=%s resource_id=%s reason=%q",
+		event.RequestID, event.Outcome, event.Subject, event.Role, event.TenantID, event.Action, event.ResourceType, event.ResourceID, event.Reason)
+
+	if r == nil {
+		return ErrNoRecorder
+	}
+	dbService, err := database.GetDatabaseService(ctx, r.cfg)
+	if err != nil {
+		return err
+	}
+	return database.NewRepository[Event](dbService).Create(ctx, &event)
+}
+
+// recorderKey is the context key of the recorder
+type recorderKey struct{}
+
+// NewContext returns a copy of ctx carrying recorder
+func NewContext(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// FromContext returns the recorder of the request being handled, or nil
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return recorder
+}
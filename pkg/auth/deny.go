@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/audit"
+	"security-questionnaire/pkg/requestid"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// RequiredPermission is the route metadata key of the permission a route
+// requires, e.g. r.GET(...).With(auth.RequiredPermission, auth.ResultsRead).
+// Routes without one are denied to everyone but admins.
+const RequiredPermission = "permission"
+
+// auditTimeout bounds how long storing an audit event may take
+const auditTimeout = 2 * time.Second
+
+// Resource identifies the record a request was denied access to
+type Resource struct {
+	Type string
+	ID   string
+}
+
+// Deny audits a denied request and returns the 403 response for it.
+// resource may be empty when the route itself is not allowed.
+func Deny(ctx context.Context, resource Resource, reason string) (events.APIGatewayV2HTTPResponse, error) {
+	record(ctx, audit.OutcomeDenied, resource, reason)
+	return respond.Fail(ctx, http.StatusForbidden, apierror.CodeForbidden, "You do not have permission to perform this action")
+}
+
+// Unauthenticated audits a request without a caller identity and returns
+// the 401 response for it
+func Unauthenticated(ctx context.Context, reason string) (events.APIGatewayV2HTTPResponse, error) {
+	record(ctx, audit.OutcomeUnauthenticated, Resource{}, reason)
+	return respond.Fail(ctx, http.StatusUnauthorized, apierror.CodeUnauthorized, "The request is not authenticated")
+}
+
+// record writes the audit event of a refused request. The refusal stands
+// even when the event cannot be stored; it is logged either way.
+func record(ctx context.Context, outcome string, resource Resource, reason string) {
+	identity := FromContext(ctx)
+	event := audit.Event{
+		RequestID:    requestid.FromContext(ctx),
+		Subject:      identity.Subject,
+		Role:         string(identity.Role),
+		TenantID:     identity.Tenant,
+		Source:       identity.Source,
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		Outcome:      outcome,
+		Reason:       reason,
+		SourceIP:     identity.SourceIP,
+	}
+	if route := router.FromContext(ctx); route != nil {
+		event.Action = route.Name
+		if event.Action == "" {
+			event.Action = route.Method + " " + route.Pattern
+		}
+	}
+
+	// Audit even when the request ran out of time, but do not wait forever
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+	defer cancel()
+	if err := audit.FromContext(ctx).Record(ctx, event); err != nil {
+		log.Printf("request_id=%s error=failed to store audit event: %v", event.RequestID, err)
+	}
+}
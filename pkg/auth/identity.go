@@ -0,0 +1,162 @@
+// Package auth identifies the caller of a request and decides what the
+// caller may do. API Gateway authenticates requests (aws_iam or a JWT
+// authorizer); this package maps the authenticated principal to a role
+// and checks the role's permissions.
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/audit"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Identity sources
+const (
+	SourceJWT    = "jwt"
+	SourceIAM    = "iam"
+	SourceHeader = "header"
+)
+
+// Headers identifying the caller when Options.TrustHeaders is set
+const (
+	RoleHeader    = "X-Actor-Role"
+	SubjectHeader = "X-Actor-Id"
+	TenantHeader  = "X-Tenant-Id"
+)
+
+// ErrUnauthenticated is returned for requests without a caller identity
+var ErrUnauthenticated = errors.New("request is not authenticated")
+
+// Identity is the authenticated caller of a request
+type Identity struct {
+	// Subject identifies the caller: the JWT subject or the IAM principal ARN
+	Subject string
+	// Role is empty when the principal is not mapped to a role
+	Role Role
+	// Tenant is the organization the caller belongs to; it scopes every
+	// record and file the caller can reach
+	Tenant string
+	Source string
+	// SourceIP is the address the request came from
+	SourceIP string
+}
+
+// Options configures how identities are resolved
+type Options struct {
+	// RoleClaim is the JWT claim holding the role, e.g. "custom:role"
+	RoleClaim string
+	// IAMRoles maps IAM role or user names to roles
+	IAMRoles map[string]Role
+	// TrustHeaders takes the identity from the X-Actor-Role, X-Actor-Id and
+	// X-Tenant-Id headers when the request carries no authorizer context.
+	// Only enable it for local development: the headers are set by the client.
+	TrustHeaders bool
+	// TenantClaim is the JWT claim holding the tenant, e.g. "custom:tenant_id"
+	TenantClaim string
+	// IAMTenants maps IAM role or user names to tenants
+	IAMTenants map[string]string
+	// DefaultTenant is the tenant of callers that carry none. Leave it empty
+	// to reject them.
+	DefaultTenant string
+	// Audit stores the events of refused requests
+	Audit *audit.Recorder
+}
+
+// OptionsFromConfig builds the identity options from the application configuration
+func OptionsFromConfig(cfg *config.Config) Options {
+	roles := make(map[string]Role, len(cfg.AuthIAMRoles))
+	for name, role := range cfg.AuthIAMRoles {
+		roles[name] = Role(strings.ToLower(role))
+	}
+	return Options{
+		RoleClaim:     cfg.AuthRoleClaim,
+		IAMRoles:      roles,
+		TrustHeaders:  cfg.AuthTrustHeaders,
+		TenantClaim:   cfg.AuthTenantClaim,
+		IAMTenants:    cfg.AuthIAMTenants,
+		DefaultTenant: cfg.AuthDefaultTenant,
+		Audit:         audit.NewRecorder(cfg),
+	}
+}
+
+// FromRequest resolves the caller of a request from its authorizer context.
+// Callers whose role cannot be determined get an identity without a role,
+// which has no permissions; callers without a tenant get DefaultTenant.
+func FromRequest(request events.APIGatewayV2HTTPRequest, opts Options) (Identity, error) {
+	identity := Identity{SourceIP: request.RequestContext.HTTP.SourceIP}
+
+	authorizer := request.RequestContext.Authorizer
+	switch {
+	case authorizer != nil && authorizer.JWT != nil:
+		claims := authorizer.JWT.Claims
+		identity.Subject = claims["sub"]
+		identity.Role = roleFromClaim(claims[opts.RoleClaim])
+		identity.Tenant = claims[opts.TenantClaim]
+		identity.Source = SourceJWT
+	case authorizer != nil && authorizer.IAM != nil && authorizer.IAM.UserARN != "":
+		identity.Subject = authorizer.IAM.UserARN
+		identity.Role = opts.IAMRoles[principalName(identity.Subject)]
+		identity.Tenant = opts.IAMTenants[principalName(identity.Subject)]
+		identity.Source = SourceIAM
+	case opts.TrustHeaders:
+		identity.Subject = router.Header(request, SubjectHeader)
+		identity.Role = Role(strings.ToLower(router.Header(request, RoleHeader)))
+		identity.Tenant = router.Header(request, TenantHeader)
+		identity.Source = SourceHeader
+	}
+	if identity.Tenant == "" {
+		identity.Tenant = opts.DefaultTenant
+	}
+
+	if identity.Subject == "" {
+		return identity, ErrUnauthenticated
+	}
+	return identity, nil
+}
+
+// roleFromClaim returns the first known role in a claim. API Gateway passes
+// list claims such as cognito:groups as "[admin analyst]".
+func roleFromClaim(claim string) Role {
+	fields := strings.FieldsFunc(strings.Trim(claim, "[]"), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	for _, field := range fields {
+		if role := Role(strings.ToLower(field)); role.Valid() {
+			return role
+		}
+	}
+	return ""
+}
+
+// principalName returns the role or user name of an IAM principal ARN:
+// "arn:aws:sts::123456789012:assumed-role/auditor/session" gives "auditor",
+// "arn:aws:iam::123456789012:user/ops/alice" gives "alice".
+func principalName(arn string) string {
+	_, resource, ok := strings.Cut(arn, ":assumed-role/")
+	if ok {
+		name, _, _ := strings.Cut(resource, "/")
+		return name
+	}
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// identityKey is the context key of the caller's identity
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying identity
+func NewContext(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the caller of the request being handled. Requests that
+// were not authorized return an identity without a role.
+func FromContext(ctx context.Context) Identity {
+	identity, _ := ctx.Value(identityKey{}).(Identity)
+	return identity
+}
@@ -0,0 +1,86 @@
+package auth
+
+// Role determines what a caller may do
+type Role string
+
+// Roles
+const (
+	// RoleAdmin may do everything
+	RoleAdmin Role = "admin"
+	// RoleAnalyst manages questionnaires and reviews results
+	RoleAnalyst Role = "analyst"
+	// RoleVendor answers questionnaires and sees only its own results and documents
+	RoleVendor Role = "vendor"
+	// RoleAuditor may read everything and change nothing
+	RoleAuditor Role = "auditor"
+)
+
+// Permission is an operation a route requires
+type Permission string
+
+// Permissions
+const (
+	DocumentsRead       Permission = "documents:read"
+	DocumentsWrite      Permission = "documents:write"
+	QuestionnairesRead  Permission = "questionnaires:read"
+	QuestionnairesWrite Permission = "questionnaires:write"
+	ResultsRead         Permission = "results:read"
+	ResultsWrite        Permission = "results:write"
+	ResultsDelete       Permission = "results:delete"
+	ResultsRecompute    Permission = "results:recompute"
+)
+
+// grants lists the permissions of each role. Admins hold every permission.
+var grants = map[Role][]Permission{
+	RoleAnalyst: {
+		DocumentsRead, DocumentsWrite,
+		QuestionnairesRead, QuestionnairesWrite,
+		ResultsRead, ResultsWrite, ResultsRecompute,
+	},
+	RoleVendor: {
+		DocumentsRead, DocumentsWrite,
+		QuestionnairesRead,
+		ResultsRead, ResultsWrite,
+	},
+	RoleAuditor: {
+		DocumentsRead, QuestionnairesRead, ResultsRead,
+	},
+}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAdmin, RoleAnalyst, RoleVendor, RoleAuditor:
+		return true
+	}
+	return false
+}
+
+// Can reports whether the role holds permission
+func (r Role) Can(permission Permission) bool {
+	if r == RoleAdmin {
+		return true
+	}
+	for _, p := range grants[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the caller holds permission
+func (i Identity) Can(permission Permission) bool {
+	return i.Role.Can(permission)
+}
+
+// Scoped reports whether the caller may only access records it owns
+func (i Identity) Scoped() bool {
+	return i.Role == RoleVendor
+}
+
+// Owns reports whether the caller may access a record owned by ownerID.
+// Callers that are not scoped to their own records may access every record.
+func (i Identity) Owns(ownerID string) bool {
+	return !i.Scoped() || (ownerID != "" && ownerID == i.Subject)
+}
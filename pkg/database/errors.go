@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Kinds of database errors. Test for them with errors.Is; the *Error
+// carrying them also exposes the violated constraint and the driver error.
+var (
+	// ErrNotFound is returned when no record matches
+	ErrNotFound = errors.New("record not found")
+	// ErrUniqueViolation is returned when a write conflicts with a unique constraint
+	ErrUniqueViolation = errors.New("unique constraint violation")
+	// ErrForeignKeyViolation is returned when a write breaks a foreign key
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	// ErrSerializationFailure is returned when a transaction lost a race with
+	// a concurrent one (serialization failure or deadlock) and may be retried
+	ErrSerializationFailure = errors.New("serialization failure")
+	// ErrTimeout is returned when a query exceeded its deadline or statement timeout
+	ErrTimeout = errors.New("database timeout")
+	// ErrUnavailable is returned when the database cannot be reached
+	ErrUnavailable = errors.New("database unavailable")
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation           = "23505"
+	pgForeignKeyViolation       = "23503"
+	pgInvalidTextRepresentation = "22P02"
+	pgSerializationFailure      = "40001"
+	pgDeadlockDetected          = "40P01"
+	pgQueryCanceled             = "57014"
+	pgTooManyConnections        = "53300"
+	pgAdminShutdown             = "57P01"
+	pgCannotConnectNow          = "57P03"
+	pgConnectionException       = "08" // class prefix
+)
+
+// Error is a classified database error
+type Error struct {
+	// Kind is one of the sentinel errors above
+	Kind error
+	// Constraint names the violated constraint, if any
+	Constraint string
+	// Err is the underlying driver error
+	Err error
+}
+
+// Error returns the kind followed by the driver error
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap lets errors.Is and errors.As see both the kind and the driver error
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// TranslateError classifies driver and GORM errors into an *Error of one of
+// the kinds above. Errors that fit no kind are returned unchanged.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Error{Kind: ErrNotFound, Err: err}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Kind: ErrTimeout, Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return &Error{Kind: ErrUniqueViolation, Constraint: pgErr.ConstraintName, Err: err}
+		case pgErr.Code == pgForeignKeyViolation:
+			return &Error{Kind: ErrForeignKeyViolation, Constraint: pgErr.ConstraintName, Err: err}
+		case pgErr.Code == pgInvalidTextRepresentation:
+			// A malformed value such as an ID that is not a UUID matches no record
+			return &Error{Kind: ErrNotFound, Err: err}
+		case pgErr.Code == pgSerializationFailure, pgErr.Code == pgDeadlockDetected:
+			return &Error{Kind: ErrSerializationFailure, Err: err}
+		case pgErr.Code == pgQueryCanceled:
+			return &Error{Kind: ErrTimeout, Err: err}
+		case pgErr.Code == pgTooManyConnections, pgErr.Code == pgAdminShutdown, pgErr.Code == pgCannotConnectNow,
+			strings.HasPrefix(pgErr.Code, pgConnectionException):
+			return &Error{Kind: ErrUnavailable, Err: err}
+		}
+		return err
+	}
+
+	if pgconn.Timeout(err) {
+		return &Error{Kind: ErrTimeout, Err: err}
+	}
+	// SafeToRetry reports errors raised before anything was sent to the server
+	if pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn) {
+		return &Error{Kind: ErrUnavailable, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return &Error{Kind: ErrTimeout, Err: err}
+		}
+		return &Error{Kind: ErrUnavailable, Err: err}
+	}
+
+	return err
+}
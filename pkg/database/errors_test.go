@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "record not found", err: gorm.ErrRecordNotFound, want: ErrNotFound},
+		{name: "malformed uuid", err: &pgconn.PgError{Code: pgInvalidTextRepresentation}, want: ErrNotFound},
+		{name: "unique violation", err: &pgconn.PgError{Code: pgUniqueViolation}, want: ErrUniqueViolation},
+		{name: "foreign key violation", err: &pgconn.PgError{Code: pgForeignKeyViolation}, want: ErrForeignKeyViolation},
+		{name: "deadlock", err: &pgconn.PgError{Code: pgDeadlockDetected}, want: ErrSerializationFailure},
+		{name: "query canceled", err: &pgconn.PgError{Code: pgQueryCanceled}, want: ErrTimeout},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: ErrUnavailable},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: ErrTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := TranslateError(tt.err); !errors.Is(err, tt.want) {
+				t.Fatalf("TranslateError(%v) = %v, want %v", tt.err, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Page sizes
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// ErrInvalidCursor is returned for cursors that are malformed or were issued for another sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageOptions selects one page of records in keyset order
+type PageOptions struct {
+	Filters []Filter
+	// Sort orders the pages; it defaults to newest first. The ID is always
+	// added as a tie-breaker so pages never overlap. The column must not be null.
+	Sort Sort
+	// Limit is clamped to MaxPageSize and defaults to DefaultPageSize
+	Limit int
+	// Cursor is a NextCursor or PrevCursor from a previous page
+	Cursor string
+	// WithTotal also counts every matching record
+	WithTotal bool
+}
+
+// Page is one page of records with the cursors of its neighbours
+type Page[T any] struct {
+	Items []T
+	// NextCursor is empty on the last page
+	NextCursor string
+	// PrevCursor is empty on the first page
+	PrevCursor string
+	// Total is set when PageOptions.WithTotal was requested
+	Total *int64
+	Limit int
+}
+
+// cursor is the decoded form of an opaque page cursor: the position of the
+// first or last record of a page in (column, id) order
+type cursor struct {
+	Column   string `json:"c"`
+	Desc     bool   `json:"d,omitempty"`
+	Value    string `json:"v"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// encode returns the opaque string form of the cursor
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses an opaque cursor
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.Column == "" || c.ID == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// cursorValue formats a column value for a cursor
+func cursorValue(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Page returns one page of records in keyset order. Unlike LIMIT/OFFSET,
+// records inserted while a client is paging never shift or repeat rows.
+func (r *Repository[T]) Page(ctx context.Context, opts PageOptions) (Page[T], error) {
+	page := Page[T]{Limit: opts.Limit}
+	if page.Limit <= 0 {
+		page.Limit = DefaultPageSize
+	}
+	if page.Limit > MaxPageSize {
+		page.Limit = MaxPageSize
+	}
+
+	sort := opts.Sort
+	if sort.Column == "" {
+		sort = Desc("created_at")
+	}
+
+	var after *cursor
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return page, err
+		}
+		if c.Column != sort.Column || c.Desc != sort.Desc {
+			return page, fmt.Errorf("%w: it was issued for another sort order", ErrInvalidCursor)
+		}
+		after = &c
+	}
+	backward := after != nil && after.Backward
+
+	db, err := r.query(ctx, opts.Filters, false)
+	if err != nil {
+		return page, err
+	}
+
+	// Walking backward reverses the order; the page is flipped back below
+	desc := sort.Desc != backward
+	if after != nil {
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		db = db.Where(clause.Expr{
+			SQL:  "(?, ?) " + op + " (?, ?)",
+			Vars: []interface{}{clause.Column{Name: sort.Column}, clause.Column{Name: "id"}, after.Value, after.ID},
+		})
+	}
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: sort.Column}, Desc: desc}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: desc}).
+		Limit(page.Limit + 1)
+
+	items := []T{}
+	if err := db.Find(&items).Error; err != nil {
+		return page, fmt.Errorf("failed to list records: %w", TranslateError(err))
+	}
+
+	more := len(items) > page.Limit
+	if more {
+		items = items[:page.Limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	page.Items = items
+
+	if len(items) > 0 {
+		first, err := r.cursorAt(ctx, sort, &items[0])
+		if err != nil {
+			return page, err
+		}
+		last, err := r.cursorAt(ctx, sort, &items[len(items)-1])
+		if err != nil {
+			return page, err
+		}
+
+		// Walking forward, later records exist if more were found and earlier
+		// ones if we came from a cursor; walking backward it is the other way round
+		if more || backward {
+			page.NextCursor = last.encode()
+		}
+		if (backward && more) || (!backward && after != nil) {
+			first.Backward = true
+			page.PrevCursor = first.encode()
+		}
+	}
+
+	if opts.WithTotal {
+		total, err := r.Count(ctx, opts.Filters...)
+		if err != nil {
+			return page, err
+		}
+		page.Total = &total
+	}
+
+	return page, nil
+}
+
+// cursorAt returns the cursor positioned at a record
+func (r *Repository[T]) cursorAt(ctx context.Context, sort Sort, item *T) (cursor, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(item); err != nil {
+		return cursor{}, fmt.Errorf("failed to parse model: %w", err)
+	}
+
+	value := reflect.ValueOf(item).Elem()
+	sortField := stmt.Schema.LookUpField(sort.Column)
+	idField := stmt.Schema.LookUpField("id")
+	if sortField == nil || idField == nil {
+		return cursor{}, fmt.Errorf("model has no %s or id column", sort.Column)
+	}
+
+	sortValue, _ := sortField.ValueOf(ctx, value)
+	id, _ := idField.ValueOf(ctx, value)
+	return cursor{Column: sort.Column, Desc: sort.Desc, Value: cursorValue(sortValue), ID: fmt.Sprint(id)}, nil
+}
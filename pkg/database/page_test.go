@@ -0,0 +1,157 @@
+package database
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"security-questionnaire/pkg/models"
+)
+
+// newPageTestService adds acme records whose names tie across page
+// boundaries, so only the id tie-breaker keeps pages apart. Ordered by
+// (name, id) the acme records are a-5, a-1, a-2, a-3, a-6, a-4.
+func newPageTestService(t *testing.T) *DatabaseService {
+	t.Helper()
+
+	s := newTenantTestService(t)
+	err := NewRepository[record](s).CreateIgnoringConflicts(acme, []record{
+		{BaseModel: models.BaseModel{ID: "a-2"}, Name: "b"},
+		{BaseModel: models.BaseModel{ID: "a-3"}, Name: "b"},
+		{BaseModel: models.BaseModel{ID: "a-4"}, Name: "c"},
+		{BaseModel: models.BaseModel{ID: "a-5"}, Name: "a"},
+		{BaseModel: models.BaseModel{ID: "a-6"}, Name: "b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func pageIDs(items []record) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		sort  Sort
+		pages [][]string
+	}{
+		{name: "ascending", sort: Asc("name"), pages: [][]string{{"a-5", "a-1"}, {"a-2", "a-3"}, {"a-6", "a-4"}}},
+		{name: "descending", sort: Desc("name"), pages: [][]string{{"a-4", "a-6"}, {"a-3", "a-2"}, {"a-1", "a-5"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewRepository[record](newPageTestService(t))
+
+			// Walk forward through every page
+			var pages []Page[record]
+			opts := PageOptions{Sort: tt.sort, Limit: 2, WithTotal: true}
+			for {
+				page, err := repo.Page(acme, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pages = append(pages, page)
+				if page.NextCursor == "" {
+					break
+				}
+				if len(pages) > len(tt.pages) {
+					t.Fatalf("more than %d pages", len(tt.pages))
+				}
+				opts.Cursor = page.NextCursor
+			}
+
+			if len(pages) != len(tt.pages) {
+				t.Fatalf("got %d pages, want %d", len(pages), len(tt.pages))
+			}
+			for i, page := range pages {
+				if got := pageIDs(page.Items); !reflect.DeepEqual(got, tt.pages[i]) {
+					t.Errorf("page %d = %v, want %v", i, got, tt.pages[i])
+				}
+				if page.Total == nil || *page.Total != 6 {
+					t.Errorf("page %d total = %v, want 6", i, page.Total)
+				}
+				if (page.PrevCursor == "") != (i == 0) {
+					t.Errorf("page %d has prev cursor %q", i, page.PrevCursor)
+				}
+			}
+
+			// Walk back from the last page
+			for i := len(pages) - 1; i > 0; i-- {
+				prev, err := repo.Page(acme, PageOptions{Sort: tt.sort, Limit: 2, Cursor: pages[i].PrevCursor})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := pageIDs(prev.Items); !reflect.DeepEqual(got, tt.pages[i-1]) {
+					t.Errorf("page before %d = %v, want %v", i, got, tt.pages[i-1])
+				}
+				if prev.NextCursor == "" {
+					t.Errorf("page before %d has no next cursor", i)
+				}
+			}
+		})
+	}
+}
+
+func TestPageRejectsInvalidCursors(t *testing.T) {
+	repo := NewRepository[record](newPageTestService(t))
+	first, err := repo.Page(acme, PageOptions{Sort: Asc("name"), Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		sort   Sort
+		cursor string
+	}{
+		{name: "not base64", sort: Asc("name"), cursor: "!!!"},
+		{name: "not json", sort: Asc("name"), cursor: base64.RawURLEncoding.EncodeToString([]byte("name"))},
+		{name: "without id", sort: Asc("name"), cursor: cursor{Column: "name", Value: "b"}.encode()},
+		{name: "other column", sort: Asc("created_at"), cursor: first.NextCursor},
+		{name: "other direction", sort: Desc("name"), cursor: first.NextCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.Page(acme, PageOptions{Sort: tt.sort, Limit: 2, Cursor: tt.cursor})
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("got error %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestPageLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultPageSize},
+		{limit: -1, want: DefaultPageSize},
+		{limit: 3, want: 3},
+		{limit: MaxPageSize + 1, want: MaxPageSize},
+	}
+
+	repo := NewRepository[record](newPageTestService(t))
+	for _, tt := range tests {
+		page, err := repo.Page(acme, PageOptions{Sort: Asc("name"), Limit: tt.limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Limit != tt.want {
+			t.Errorf("Limit %d gave page limit %d, want %d", tt.limit, page.Limit, tt.want)
+		}
+		if len(page.Items) > tt.want {
+			t.Errorf("Limit %d returned %d records", tt.limit, len(page.Items))
+		}
+	}
+}
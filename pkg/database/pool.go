@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"security-questionnaire/config"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// healthCheckTimeout bounds how long a health check ping may take
+const healthCheckTimeout = 2 * time.Second
+
+// PoolConfig configures the connection pool of a database service
+type PoolConfig struct {
+	MaxOpenConns        int
+	MaxIdleConns        int
+	ConnMaxLifetime     time.Duration
+	ConnMaxIdleTime     time.Duration
+	HealthCheckInterval time.Duration
+}
+
+// PoolConfigFromConfig returns the pool settings of the application configuration
+func PoolConfigFromConfig(cfg *config.Config) PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:        cfg.DBMaxOpenConns,
+		MaxIdleConns:        cfg.DBMaxIdleConns,
+		ConnMaxLifetime:     cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime:     cfg.DBConnMaxIdleTime,
+		HealthCheckInterval: cfg.DBHealthCheckInterval,
+	}
+}
+
+// sharedPool holds the process-wide database service. A warm Lambda
+// container keeps it between invocations, so only cold starts connect.
+var sharedPool struct {
+	mu          sync.Mutex
+	service     *DatabaseService
+	databaseURL string
+	lastCheck   time.Time
+}
+
+// GetDatabaseService returns the process-wide database service, connecting
+// lazily on first use. The connection is health checked at most once per
+// HealthCheckInterval and re-established if the check fails. The returned
+// service is shared and must not be closed by callers. Connecting and
+// health checking stop when ctx is done.
+func GetDatabaseService(ctx context.Context, cfg *config.Config) (*DatabaseService, error) {
+	pool := PoolConfigFromConfig(cfg)
+
+	sharedPool.mu.Lock()
+	defer sharedPool.mu.Unlock()
+
+	if s := sharedPool.service; s != nil && sharedPool.databaseURL == cfg.DatabaseURL {
+		if time.Since(sharedPool.lastCheck) < pool.HealthCheckInterval {
+			return s, nil
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := s.Ping(pingCtx)
+		cancel()
+		if err == nil {
+			sharedPool.lastCheck = time.Now()
+			return s, nil
+		}
+		// The caller ran out of time; that says nothing about the connection
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("database health check failed: %w", TranslateError(ctx.Err()))
+		}
+
+		log.Printf("Database health check failed, reconnecting: %v", err)
+		_ = s.closePool()
+		sharedPool.service = nil
+	}
+
+	s, err := Open(ctx, cfg.DatabaseURL, pool)
+	if err != nil {
+		return nil, err
+	}
+	s.shared = true
+
+	sharedPool.service = s
+	sharedPool.databaseURL = cfg.DatabaseURL
+	sharedPool.lastCheck = time.Now()
+	return s, nil
+}
+
+// Open connects to the database with the given pool settings and verifies the connection
+func Open(ctx context.Context, databaseURL string, pool PoolConfig) (*DatabaseService, error) {
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+		// The connection is verified below with a ping bounded by ctx
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", &Error{Kind: ErrUnavailable, Err: err})
+	}
+	if err := registerTenantScope(db); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+
+	s := &DatabaseService{db: db}
+
+	pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := s.Ping(pingCtx); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Ping checks that the database is reachable
+func (s *DatabaseService) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to access connection pool: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		if translated := TranslateError(err); translated != err {
+			return fmt.Errorf("database health check failed: %w", translated)
+		}
+		return fmt.Errorf("database health check failed: %w", &Error{Kind: ErrUnavailable, Err: err})
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Operator is a comparison used by a Filter
+type Operator string
+
+// Filter operators
+const (
+	OpEq       Operator = "eq"
+	OpNotEq    Operator = "not_eq"
+	OpGt       Operator = "gt"
+	OpGte      Operator = "gte"
+	OpLt       Operator = "lt"
+	OpLte      Operator = "lte"
+	OpIn       Operator = "in"
+	OpContains Operator = "contains"
+	// OpListContains matches an element of a comma-separated text column
+	OpListContains Operator = "list_contains"
+)
+
+// Filter restricts a query to records whose column compares to a value
+type Filter struct {
+	Column   string
+	Operator Operator
+	Value    interface{}
+}
+
+// Eq matches records whose column equals value
+func Eq(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpEq, Value: value}
+}
+
+// NotEq matches records whose column differs from value
+func NotEq(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpNotEq, Value: value}
+}
+
+// Gt matches records whose column is greater than value
+func Gt(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpGt, Value: value}
+}
+
+// Gte matches records whose column is greater than or equal to value
+func Gte(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpGte, Value: value}
+}
+
+// Lt matches records whose column is less than value
+func Lt(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpLt, Value: value}
+}
+
+// Lte matches records whose column is less than or equal to value
+func Lte(column string, value interface{}) Filter {
+	return Filter{Column: column, Operator: OpLte, Value: value}
+}
+
+// In matches records whose column is one of values
+func In[V any](column string, values []V) Filter {
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	return Filter{Column: column, Operator: OpIn, Value: items}
+}
+
+// Contains matches records whose text column contains value, ignoring case
+func Contains(column string, value string) Filter {
+	return Filter{Column: column, Operator: OpContains, Value: value}
+}
+
+// ListContains matches records whose comma-separated text column (such as
+// "security, soc2") has value as one of its elements, ignoring case and spaces
+func ListContains(column string, value string) Filter {
+	return Filter{Column: column, Operator: OpListContains, Value: value}
+}
+
+// expression converts the filter to a GORM clause expression
+func (f Filter) expression() (clause.Expression, error) {
+	column := clause.Column{Name: f.Column}
+	switch f.Operator {
+	case OpEq:
+		return clause.Eq{Column: column, Value: f.Value}, nil
+	case OpNotEq:
+		return clause.Neq{Column: column, Value: f.Value}, nil
+	case OpGt:
+		return clause.Gt{Column: column, Value: f.Value}, nil
+	case OpGte:
+		return clause.Gte{Column: column, Value: f.Value}, nil
+	case OpLt:
+		return clause.Lt{Column: column, Value: f.Value}, nil
+	case OpLte:
+		return clause.Lte{Column: column, Value: f.Value}, nil
+	case OpIn:
+		values, _ := f.Value.([]interface{})
+		return clause.IN{Column: column, Values: values}, nil
+	case OpContains:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, "%" + escapeLike(fmt.Sprint(f.Value)) + "%"}}, nil
+	case OpListContains:
+		return clause.Expr{
+			SQL:  `lower(?) = ANY(string_to_array(regexp_replace(lower(btrim(?)), '\s*,\s*', ',', 'g'), ','))`,
+			Vars: []interface{}{strings.TrimSpace(fmt.Sprint(f.Value)), column},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown filter operator %q", f.Operator)
+	}
+}
+
+// Sort orders records by a column
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+// Asc sorts by column in ascending order
+func Asc(column string) Sort {
+	return Sort{Column: column}
+}
+
+// Desc sorts by column in descending order
+func Desc(column string) Sort {
+	return Sort{Column: column, Desc: true}
+}
+
+// ListOptions selects, orders and pages the records returned by List
+type ListOptions struct {
+	Filters []Filter
+	// Sort defaults to newest first
+	Sort   []Sort
+	Limit  int
+	Offset int
+	// WithDeleted includes soft-deleted records
+	WithDeleted bool
+	// ForUpdate locks the matching records (SELECT ... FOR UPDATE) until the
+	// transaction ends; use it on a repository of WithTransaction's service
+	ForUpdate bool
+}
+
+// defaultSort lists the newest records first, with the ID as tie-breaker
+var defaultSort = []Sort{Desc("created_at"), Desc("id")}
+
+// Repository provides typed CRUD operations for a model. Models embedding
+// BaseModel are soft deleted: Delete sets deleted_at and queries skip
+// deleted records unless asked otherwise. They are also tenant-scoped: every
+// operation only sees the records of the tenant in ctx, even WithDeleted,
+// and Create assigns records to it.
+type Repository[T any] struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a repository for T on the database service
+func NewRepository[T any](s *DatabaseService) *Repository[T] {
+	return &Repository[T]{db: s.db}
+}
+
+// WithTx returns a repository that runs its queries in the transaction tx
+func (r *Repository[T]) WithTx(tx *gorm.DB) *Repository[T] {
+	return &Repository[T]{db: tx}
+}
+
+// query starts a statement on T with the given filters
+func (r *Repository[T]) query(ctx context.Context, filters []Filter, withDeleted bool) (*gorm.DB, error) {
+	db := r.db.WithContext(ctx).Model(new(T))
+	if withDeleted {
+		db = db.Unscoped()
+	}
+	for _, f := range filters {
+		expr, err := f.expression()
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where(expr)
+	}
+	return db, nil
+}
+
+// Get returns the record with the given ID
+func (r *Repository[T]) Get(ctx context.Context, id string) (T, error) {
+	return r.First(ctx, ListOptions{Filters: []Filter{Eq("id", id)}})
+}
+
+// First returns the first record matching the options
+func (r *Repository[T]) First(ctx context.Context, opts ListOptions) (T, error) {
+	var entity T
+
+	opts.Limit = 1
+	records, err := r.List(ctx, opts)
+	if err != nil {
+		return entity, err
+	}
+	if len(records) == 0 {
+		return entity, fmt.Errorf("failed to get record: %w", ErrNotFound)
+	}
+	return records[0], nil
+}
+
+// List returns the records matching the options
+func (r *Repository[T]) List(ctx context.Context, opts ListOptions) ([]T, error) {
+	db, err := r.query(ctx, opts.Filters, opts.WithDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	sorts := opts.Sort
+	if len(sorts) == 0 {
+		sorts = defaultSort
+	}
+	for _, s := range sorts {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: s.Column}, Desc: s.Desc})
+	}
+	if opts.Limit > 0 {
+		db = db.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		db = db.Offset(opts.Offset)
+	}
+	if opts.ForUpdate {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	records := []T{}
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", TranslateError(err))
+	}
+	return records, nil
+}
+
+// Count returns how many records match the filters
+func (r *Repository[T]) Count(ctx context.Context, filters ...Filter) (int64, error) {
+	db, err := r.query(ctx, filters, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count records: %w", TranslateError(err))
+	}
+	return total, nil
+}
+
+// InBatches calls fn with the records matching the filters, batchSize at a time
+func (r *Repository[T]) InBatches(ctx context.Context, filters []Filter, batchSize int, fn func(batch []T) error) error {
+	db, err := r.query(ctx, filters, false)
+	if err != nil {
+		return err
+	}
+
+	var batch []T
+	err = db.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to process records: %w", TranslateError(err))
+	}
+	return nil
+}
+
+// Create inserts a new record and fills in its generated fields
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to create record: %w", TranslateError(err))
+	}
+	return nil
+}
+
+// CreateIgnoringConflicts inserts the records, skipping those that conflict
+// with an existing record on a unique constraint (ON CONFLICT DO NOTHING)
+func (r *Repository[T]) CreateIgnoringConflicts(ctx context.Context, entities []T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&entities).Error; err != nil {
+		return fmt.Errorf("failed to create records: %w", TranslateError(err))
+	}
+	return nil
+}
+
+// Update applies updates to the record with the given ID and returns the updated record
+func (r *Repository[T]) Update(ctx context.Context, id string, updates map[string]interface{}) (T, error) {
+	entity, err := r.Get(ctx, id)
+	if err != nil {
+		return entity, err
+	}
+	if err := r.Patch(ctx, &entity, updates); err != nil {
+		return entity, err
+	}
+	return entity, nil
+}
+
+// Patch applies updates to a loaded record and to its fields
+func (r *Repository[T]) Patch(ctx context.Context, entity *T, updates map[string]interface{}) error {
+	if err := r.db.WithContext(ctx).Model(entity).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update record: %w", TranslateError(err))
+	}
+	return nil
+}
+
+// Delete soft deletes the record with the given ID
+func (r *Repository[T]) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Delete(new(T), "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete record: %w", TranslateError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("failed to delete record: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// Purge permanently deletes the records matching the filters, including
+// soft-deleted ones, and returns how many were removed. At least one filter
+// is required so a table is never wiped by accident.
+func (r *Repository[T]) Purge(ctx context.Context, filters ...Filter) (int64, error) {
+	if len(filters) == 0 {
+		return 0, errors.New("purge requires at least one filter")
+	}
+
+	db, err := r.query(ctx, filters, true)
+	if err != nil {
+		return 0, err
+	}
+
+	result := db.Delete(new(T))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete records: %w", TranslateError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+// escapeLike escapes the wildcard characters of a LIKE pattern
+func escapeLike(s string) string {
+	var escaped []rune
+	for _, r := range s {
+		if r == '\\' || r == '%' || r == '_' {
+			escaped = append(escaped, '\\')
+		}
+		escaped = append(escaped, r)
+	}
+	return string(escaped)
+}
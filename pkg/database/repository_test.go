@@ -0,0 +1,95 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"security-questionnaire/pkg/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestCreateIgnoringConflicts(t *testing.T) {
+	s := newTenantTestService(t)
+	repo := NewRepository[record](s)
+
+	err := repo.CreateIgnoringConflicts(acme, []record{
+		{BaseModel: models.BaseModel{ID: "a-1"}, Name: "duplicate"},
+		{BaseModel: models.BaseModel{ID: "a-2"}, Name: "new"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]string{"a-1": "acme", "a-2": "new"} {
+		stored, err := repo.Get(acme, id)
+		if err != nil {
+			t.Fatalf("record %s: %v", id, err)
+		}
+		if stored.Name != want {
+			t.Errorf("record %s is named %q, want %q", id, stored.Name, want)
+		}
+	}
+
+	if err := repo.CreateIgnoringConflicts(acme, nil); err != nil {
+		t.Fatalf("creating no records: %v", err)
+	}
+}
+
+func TestFilterExpression(t *testing.T) {
+	s := newTenantTestService(t)
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		wantSQL  string
+		wantVars []interface{}
+		wantErr  bool
+	}{
+		{name: "eq", filter: Eq("name", "acme"), wantSQL: "`name` = ?", wantVars: []interface{}{"acme"}},
+		{name: "in", filter: In("id", []string{"a-1", "a-2"}), wantSQL: "`id` IN (?,?)", wantVars: []interface{}{"a-1", "a-2"}},
+		{name: "in without values", filter: In("id", []string{}), wantSQL: "`id` IN (NULL)"},
+		{name: "contains", filter: Contains("name", "50%_off"), wantSQL: "`name` ILIKE ?", wantVars: []interface{}{`%50\%\_off%`}},
+		{name: "unknown operator", filter: Filter{Column: "name", Operator: "like", Value: "a"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := tt.filter.expression()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expression() = %v, want an error", expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stmt := &gorm.Statement{DB: s.db, Clauses: map[string]clause.Clause{}}
+			expr.Build(stmt)
+			if sql := stmt.SQL.String(); sql != tt.wantSQL {
+				t.Errorf("SQL = %q, want %q", sql, tt.wantSQL)
+			}
+			if len(stmt.Vars) != len(tt.wantVars) || (len(tt.wantVars) > 0 && !reflect.DeepEqual(stmt.Vars, tt.wantVars)) {
+				t.Errorf("vars = %v, want %v", stmt.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestListInFilter(t *testing.T) {
+	repo := NewRepository[record](newPageTestService(t))
+
+	records, err := repo.List(acme, ListOptions{
+		Filters: []Filter{In("id", []string{"a-2", "a-4", "b-1"})},
+		Sort:    []Sort{Asc("id")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pageIDs(records), []string{"a-2", "a-4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("listed %v, want %v", got, want)
+	}
+}
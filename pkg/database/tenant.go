@@ -0,0 +1,217 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"security-questionnaire/pkg/tenant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TenantColumn holds the tenant of the records of tenant-scoped models
+const TenantColumn = "tenant_id"
+
+var (
+	// ErrTenantChange is returned by updates that try to move a record to another tenant
+	ErrTenantChange = errors.New("the tenant of a record cannot be changed")
+
+	// ErrUnscopedStatement is returned by statements that cannot be confined
+	// to the tenant of their context: raw SQL and statements without a model
+	ErrUnscopedStatement = errors.New("statement cannot be scoped to a tenant")
+)
+
+// tenantScoped is implemented by models whose records belong to a tenant,
+// i.e. those embedding models.BaseModel
+type tenantScoped interface {
+	TenantScoped()
+}
+
+// registerTenantScope installs the callbacks that confine every statement on
+// a tenant-scoped model to the tenant of its context (see pkg/tenant).
+// Queries, counts, updates and deletes only match the tenant's records and
+// creates stamp them with it, including statements built through GetDB.
+// Statements without a tenant in their context fail with tenant.ErrMissing.
+//
+// Raw SQL (Raw, Exec) and statements without a model (Table with a map
+// destination) cannot be scoped, so they fail with ErrUnscopedStatement when
+// their context carries a tenant. They are only allowed in tenant-less
+// contexts, i.e. migrations; savepoints of nested transactions are exempt.
+func registerTenantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	registrations := []error{
+		callbacks.Query().Before("gorm:query").Register("tenant:query", scopeToTenant),
+		callbacks.Row().Before("gorm:row").Register("tenant:row", scopeToTenant),
+		callbacks.Raw().Before("gorm:raw").Register("tenant:raw", rejectUnscoped),
+		callbacks.Update().Before("gorm:update").Register("tenant:update", scopeUpdateToTenant),
+		callbacks.Delete().Before("gorm:delete").Register("tenant:delete", scopeToTenant),
+		callbacks.Create().Before("gorm:create").Register("tenant:create", assignTenant),
+	}
+	if err := errors.Join(registrations...); err != nil {
+		return fmt.Errorf("failed to register tenant scope: %w", err)
+	}
+	return nil
+}
+
+// statementTenant returns the tenant of a statement on a tenant-scoped model.
+// ok is false for models that are not tenant-scoped and for statements that
+// cannot be scoped, which rejectUnscoped fails when they carry a tenant.
+func statementTenant(db *gorm.DB) (id string, ok bool) {
+	if db.Statement.Schema == nil || db.Statement.SQL.Len() > 0 {
+		rejectUnscoped(db)
+		return "", false
+	}
+	if !isTenantScoped(db.Statement.Schema) {
+		return "", false
+	}
+
+	id = tenant.FromContext(db.Statement.Context)
+	if id == "" {
+		_ = db.AddError(fmt.Errorf("%w: cannot access %s", tenant.ErrMissing, db.Statement.Schema.Table))
+	}
+	return id, true
+}
+
+// rejectUnscoped fails raw SQL and statements without a model when their
+// context carries a tenant, since no tenant condition can be added to them
+func rejectUnscoped(db *gorm.DB) {
+	if tenant.FromContext(db.Statement.Context) == "" || isSavePoint(db.Statement.SQL.String()) {
+		return
+	}
+	statement := db.Statement.Table
+	if db.Statement.SQL.Len() > 0 {
+		statement = db.Statement.SQL.String()
+	}
+	_ = db.AddError(fmt.Errorf("%w: %s", ErrUnscopedStatement, statement))
+}
+
+// isSavePoint reports whether sql manages a savepoint of a nested transaction
+func isSavePoint(sql string) bool {
+	for _, prefix := range []string{"SAVEPOINT ", "RELEASE SAVEPOINT ", "ROLLBACK TO SAVEPOINT "} {
+		if strings.HasPrefix(sql, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTenantScoped reports whether the records of a model belong to a tenant
+func isTenantScoped(s *schema.Schema) bool {
+	_, ok := reflect.New(s.ModelType).Interface().(tenantScoped)
+	return ok && s.LookUpField(TenantColumn) != nil
+}
+
+// scopeToTenant restricts a statement to the records of its tenant
+func scopeToTenant(db *gorm.DB) {
+	id, ok := statementTenant(db)
+	if !ok || id == "" {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: TenantColumn}, Value: id},
+	}})
+}
+
+// scopeUpdateToTenant restricts an update to the records of its tenant and
+// refuses to change their tenant, whether the new values come from a map,
+// a struct (Updates(&record), Save) or a slice of them
+func scopeUpdateToTenant(db *gorm.DB) {
+	id, ok := statementTenant(db)
+	if !ok || id == "" {
+		return
+	}
+	if changesTenant(db, id) {
+		_ = db.AddError(ErrTenantChange)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: TenantColumn}, Value: id},
+	}})
+}
+
+// changesTenant reports whether the values of an update set the tenant of
+// its records to anything but id
+func changesTenant(db *gorm.DB, id string) bool {
+	field := db.Statement.Schema.LookUpField(TenantColumn)
+	if updates, ok := db.Statement.Dest.(map[string]interface{}); ok {
+		for _, name := range []string{field.DBName, field.Name} {
+			if value, ok := updates[name]; ok && value != id {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Like GORM, update zero fields only when selected (Save selects "*")
+	selected, restricted := db.Statement.SelectAndOmitColumns(false, true)
+	include, listed := selected[field.DBName]
+	if listed && !include {
+		return false
+	}
+	changes := func(record reflect.Value) bool {
+		record = reflect.Indirect(record)
+		if record.Kind() != reflect.Struct {
+			return false
+		}
+		value := record.FieldByName(field.Name)
+		if !value.IsValid() || value.Kind() != reflect.String {
+			return false
+		}
+		if value.String() == "" {
+			return listed
+		}
+		return (listed || !restricted) && value.String() != id
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(db.Statement.Dest))
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if changes(value.Index(i)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return changes(value)
+	}
+}
+
+// assignTenant stamps the records being created with the statement's tenant,
+// overwriting whatever tenant they carried. Upserts (Save falls back to one)
+// only update conflicting records of the same tenant.
+func assignTenant(db *gorm.DB) {
+	id, ok := statementTenant(db)
+	if !ok || id == "" {
+		return
+	}
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, clause.Eq{
+				Column: clause.Column{Table: clause.CurrentTable, Name: TenantColumn}, Value: id,
+			})
+			db.Statement.AddClause(onConflict)
+		}
+	}
+
+	field := db.Statement.Schema.LookUpField(TenantColumn)
+	ctx := db.Statement.Context
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), id); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, value, id); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
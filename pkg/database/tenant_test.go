@@ -0,0 +1,338 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"security-questionnaire/pkg/models"
+	"security-questionnaire/pkg/tenant"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// record is a tenant-scoped model for the tests
+type record struct {
+	models.BaseModel
+	Name string `gorm:"column:name"`
+}
+
+func (record) TableName() string {
+	return "records"
+}
+
+var (
+	acme   = tenant.NewContext(context.Background(), "acme")
+	globex = tenant.NewContext(context.Background(), "globex")
+)
+
+// newTenantTestService opens an in-memory database holding the acme record
+// "a-1" and the globex record "b-1"
+func newTenantTestService(t *testing.T) *DatabaseService {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := registerTenantScope(db); err != nil {
+		t.Fatal(err)
+	}
+	// Schema changes run without a tenant, like migrations
+	err = db.Exec(`CREATE TABLE records (
+		id TEXT PRIMARY KEY,
+		tenant_id TEXT NOT NULL,
+		name TEXT,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	)`).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &DatabaseService{db: db}
+	repo := NewRepository[record](s)
+	if err := repo.Create(acme, &record{BaseModel: models.BaseModel{ID: "a-1"}, Name: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(globex, &record{BaseModel: models.BaseModel{ID: "b-1"}, Name: "globex"}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestTenantScopeHidesOtherTenants(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(s *DatabaseService, repo *Repository[record]) error
+		wantErr error
+	}{
+		{
+			name: "get",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				_, err := repo.Get(acme, "b-1")
+				return err
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "list",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				records, err := repo.List(acme, ListOptions{WithDeleted: true})
+				if err == nil && (len(records) != 1 || records[0].ID != "a-1") {
+					err = fmt.Errorf("listed %+v, want only a-1", records)
+				}
+				return err
+			},
+		},
+		{
+			name: "count",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				total, err := repo.Count(acme)
+				if err == nil && total != 1 {
+					err = fmt.Errorf("counted %d records, want 1", total)
+				}
+				return err
+			},
+		},
+		{
+			name: "update",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				_, err := repo.Update(acme, "b-1", map[string]interface{}{"name": "acme"})
+				return err
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "patch",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				foreign := record{BaseModel: models.BaseModel{ID: "b-1", TenantID: "acme"}}
+				return repo.Patch(acme, &foreign, map[string]interface{}{"name": "acme"})
+			},
+		},
+		{
+			name: "save",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				foreign := record{BaseModel: models.BaseModel{ID: "b-1", TenantID: "acme"}, Name: "acme"}
+				return s.GetDB().WithContext(acme).Save(&foreign).Error
+			},
+		},
+		{
+			name: "delete",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				return repo.Delete(acme, "b-1")
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "purge",
+			run: func(s *DatabaseService, repo *Repository[record]) error {
+				purged, err := repo.Purge(acme, Eq("id", "b-1"))
+				if err == nil && purged != 0 {
+					err = fmt.Errorf("purged %d records, want 0", purged)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTenantTestService(t)
+			repo := NewRepository[record](s)
+
+			if err := tt.run(s, repo); !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			foreign, err := repo.Get(globex, "b-1")
+			if err != nil {
+				t.Fatalf("record of globex is gone: %v", err)
+			}
+			if foreign.TenantID != "globex" || foreign.Name != "globex" {
+				t.Fatalf("record of globex changed to %+v", foreign)
+			}
+		})
+	}
+}
+
+func TestAssignTenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []record
+	}{
+		{name: "without tenant", records: []record{{BaseModel: models.BaseModel{ID: "a-2"}}}},
+		{name: "with another tenant", records: []record{{BaseModel: models.BaseModel{ID: "a-2", TenantID: "globex"}}}},
+		{name: "batch", records: []record{
+			{BaseModel: models.BaseModel{ID: "a-2"}},
+			{BaseModel: models.BaseModel{ID: "a-3", TenantID: "globex"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTenantTestService(t)
+			if err := s.GetDB().WithContext(acme).Create(&tt.records).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			repo := NewRepository[record](s)
+			for _, created := range tt.records {
+				if created.TenantID != "acme" {
+					t.Errorf("created %s with tenant %q, want acme", created.ID, created.TenantID)
+				}
+				stored, err := repo.Get(acme, created.ID)
+				if err != nil {
+					t.Fatalf("record %s is not visible to acme: %v", created.ID, err)
+				}
+				if stored.TenantID != "acme" {
+					t.Errorf("stored %s with tenant %q, want acme", created.ID, stored.TenantID)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantScopeRejectsStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     func(s *DatabaseService, own *record) error
+		wantErr error
+	}{
+		{
+			name: "no tenant",
+			run: func(s *DatabaseService, own *record) error {
+				_, err := NewRepository[record](s).Get(context.Background(), "a-1")
+				return err
+			},
+			wantErr: tenant.ErrMissing,
+		},
+		{
+			name: "map changing the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				return NewRepository[record](s).Patch(acme, own, map[string]interface{}{"tenant_id": "globex"})
+			},
+			wantErr: ErrTenantChange,
+		},
+		{
+			name: "map keeping the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				return NewRepository[record](s).Patch(acme, own, map[string]interface{}{"tenant_id": "acme", "name": "renamed"})
+			},
+		},
+		{
+			name: "struct changing the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				return s.GetDB().WithContext(acme).Model(own).
+					Updates(&record{BaseModel: models.BaseModel{TenantID: "globex"}}).Error
+			},
+			wantErr: ErrTenantChange,
+		},
+		{
+			name: "struct without a tenant",
+			run: func(s *DatabaseService, own *record) error {
+				return s.GetDB().WithContext(acme).Model(own).Updates(&record{Name: "renamed"}).Error
+			},
+		},
+		{
+			name: "save changing the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				own.TenantID = "globex"
+				return s.GetDB().WithContext(acme).Save(own).Error
+			},
+			wantErr: ErrTenantChange,
+		},
+		{
+			name: "save clearing the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				own.TenantID = ""
+				return s.GetDB().WithContext(acme).Save(own).Error
+			},
+			wantErr: ErrTenantChange,
+		},
+		{
+			name: "save keeping the tenant",
+			run: func(s *DatabaseService, own *record) error {
+				own.Name = "renamed"
+				return s.GetDB().WithContext(acme).Save(own).Error
+			},
+		},
+		{
+			name: "exec",
+			run: func(s *DatabaseService, own *record) error {
+				return s.GetDB().WithContext(acme).Exec("DELETE FROM records").Error
+			},
+			wantErr: ErrUnscopedStatement,
+		},
+		{
+			name: "raw",
+			run: func(s *DatabaseService, own *record) error {
+				var records []record
+				return s.GetDB().WithContext(acme).Raw("SELECT * FROM records").Scan(&records).Error
+			},
+			wantErr: ErrUnscopedStatement,
+		},
+		{
+			name: "raw into a model",
+			run: func(s *DatabaseService, own *record) error {
+				var records []record
+				return s.GetDB().WithContext(acme).Raw("SELECT * FROM records").Find(&records).Error
+			},
+			wantErr: ErrUnscopedStatement,
+		},
+		{
+			name: "table without a model",
+			run: func(s *DatabaseService, own *record) error {
+				var rows []map[string]interface{}
+				return s.GetDB().WithContext(acme).Table("records").Find(&rows).Error
+			},
+			wantErr: ErrUnscopedStatement,
+		},
+		{
+			name: "nested transaction",
+			run: func(s *DatabaseService, own *record) error {
+				return s.WithTransaction(acme, func(tx *DatabaseService) error {
+					return tx.WithTransaction(acme, func(tx *DatabaseService) error {
+						_, err := NewRepository[record](tx).Get(acme, "a-1")
+						return err
+					})
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTenantTestService(t)
+			own, err := NewRepository[record](s).Get(acme, "a-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.run(s, &own); !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			for ctx, id := range map[context.Context]string{acme: "a-1", globex: "b-1"} {
+				stored, err := NewRepository[record](s).Get(ctx, id)
+				if err != nil {
+					t.Fatalf("record %s is gone: %v", id, err)
+				}
+				if want := tenant.FromContext(ctx); stored.TenantID != want {
+					t.Fatalf("record %s moved to tenant %q, want %q", id, stored.TenantID, want)
+				}
+			}
+		})
+	}
+}
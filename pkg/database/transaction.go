@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Retry policy of WithTransaction for transactions that lost a race
+const (
+	maxTxAttempts  = 3
+	txRetryBackoff = 25 * time.Millisecond
+)
+
+// WithTransaction runs fn in a transaction and commits it if fn returns nil.
+// fn receives a service bound to the transaction: repositories created from
+// it with NewRepository read and write inside the transaction.
+//
+// Called on a transaction's service, WithTransaction runs fn in a savepoint
+// instead, so a failing fn only undoes its own writes; opts are ignored.
+//
+// A top-level transaction that fails with ErrSerializationFailure is rolled
+// back and run again, up to three times in total, so fn must not have side
+// effects outside the database. Errors are classified with TranslateError.
+func (s *DatabaseService) WithTransaction(ctx context.Context, fn func(tx *DatabaseService) error, opts ...*sql.TxOptions) error {
+	if s.inTx {
+		// A serialization failure aborts the whole transaction, so only
+		// the outermost call may retry
+		return TranslateError(s.transaction(ctx, fn, opts))
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := TranslateError(s.transaction(ctx, fn, opts))
+		if err == nil || attempt == maxTxAttempts || !errors.Is(err, ErrSerializationFailure) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return TranslateError(ctx.Err())
+		case <-time.After(retryDelay(attempt)):
+		}
+	}
+}
+
+// InTransaction reports whether the service is bound to a transaction
+func (s *DatabaseService) InTransaction() bool {
+	return s.inTx
+}
+
+// transaction runs fn once in a transaction, or in a savepoint when the
+// service is already bound to one
+func (s *DatabaseService) transaction(ctx context.Context, fn func(tx *DatabaseService) error, opts []*sql.TxOptions) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&DatabaseService{db: tx, shared: true, inTx: true})
+	}, opts...)
+}
+
+// retryDelay doubles the backoff with every attempt and adds jitter so
+// competing transactions do not collide again
+func retryDelay(attempt int) time.Duration {
+	backoff := txRetryBackoff << (attempt - 1)
+	return backoff + time.Duration(rand.Int63n(int64(backoff)))
+}
@@ -0,0 +1,177 @@
+// Package httpadapter serves API Gateway HTTP API (payload v2) handlers
+// from a net/http server, so the services can run without Lambda.
+package httpadapter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+)
+
+// maxBodySize matches the 10 MB request payload limit of API Gateway
+const maxBodySize = 10 << 20
+
+// Handler returns an http.Handler that translates each request into an
+// APIGatewayV2HTTPRequest, calls handler and writes its response back.
+// Path parameters are filled in by the router, as with API Gateway.
+func Handler(handler router.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, err := NewRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err := handler(r.Context(), request)
+		if err != nil {
+			log.Printf("request_id=%s handler error: %v", request.RequestContext.RequestID, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		if err := WriteResponse(w, response); err != nil {
+			log.Printf("request_id=%s failed to write response: %v", request.RequestContext.RequestID, err)
+		}
+	})
+}
+
+// NewRequest converts an HTTP request into the event API Gateway would send.
+// Header names are lowercased and repeated headers and query parameters are
+// joined with commas. Bodies that are not text are base64 encoded.
+func NewRequest(r *http.Request) (events.APIGatewayV2HTTPRequest, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return events.APIGatewayV2HTTPRequest{}, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(body) > maxBodySize {
+		return events.APIGatewayV2HTTPRequest{}, fmt.Errorf("request body exceeds %d bytes", maxBodySize)
+	}
+
+	headers := make(map[string]string, len(r.Header)+1)
+	for name, values := range r.Header {
+		if strings.EqualFold(name, "Cookie") {
+			continue
+		}
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	headers["host"] = r.Host
+
+	var query map[string]string
+	if values := r.URL.Query(); len(values) > 0 {
+		query = make(map[string]string, len(values))
+		for name, value := range values {
+			query[name] = strings.Join(value, ",")
+		}
+	}
+
+	var cookies []string
+	for _, cookie := range r.Cookies() {
+		cookies = append(cookies, cookie.String())
+	}
+
+	sourceIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		sourceIP = r.RemoteAddr
+	}
+
+	now := time.Now()
+	request := events.APIGatewayV2HTTPRequest{
+		Version:               "2.0",
+		RouteKey:              "$default",
+		RawPath:               r.URL.EscapedPath(),
+		RawQueryString:        r.URL.RawQuery,
+		Cookies:               cookies,
+		Headers:               headers,
+		QueryStringParameters: query,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RouteKey:   "$default",
+			Stage:      "$default",
+			RequestID:  uuid.New().String(),
+			DomainName: r.Host,
+			Time:       now.UTC().Format("02/Jan/2006:15:04:05 -0700"),
+			TimeEpoch:  now.UnixMilli(),
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Protocol:  r.Proto,
+				SourceIP:  sourceIP,
+				UserAgent: r.UserAgent(),
+			},
+		},
+	}
+
+	if len(body) > 0 {
+		if isText(r.Header.Get("Content-Type")) {
+			request.Body = string(body)
+		} else {
+			request.Body = base64.StdEncoding.EncodeToString(body)
+			request.IsBase64Encoded = true
+		}
+	}
+
+	return request, nil
+}
+
+// WriteResponse writes an API Gateway response to w, decoding base64 bodies
+func WriteResponse(w http.ResponseWriter, response events.APIGatewayV2HTTPResponse) error {
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			http.Error(w, "Invalid base64 response body", http.StatusBadGateway)
+			return fmt.Errorf("failed to decode response body: %w", err)
+		}
+		body = decoded
+	}
+
+	header := w.Header()
+	for name, value := range response.Headers {
+		header.Set(name, value)
+	}
+	for name, values := range response.MultiValueHeaders {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+	for _, cookie := range response.Cookies {
+		header.Add("Set-Cookie", cookie)
+	}
+
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+
+	_, err := w.Write(body)
+	return err
+}
+
+// isText reports whether API Gateway would pass a body of this content
+// type as text rather than base64
+func isText(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType == ""
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "json"),
+		strings.HasSuffix(mediaType, "xml"),
+		mediaType == "application/javascript",
+		mediaType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
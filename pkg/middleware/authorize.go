@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"security-questionnaire/pkg/audit"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/router"
+	"security-questionnaire/pkg/tenant"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Authorize resolves the caller of every routed request, stores it in the
+// context for handlers (auth.FromContext) and enforces the permission the
+// route requires. Callers without an identity get 401, callers lacking the
+// permission or a tenant 403; both are audited. Handlers run with the
+// caller's tenant in the context (see pkg/tenant). Unmatched requests pass through so they
+// get their 404 or 405.
+func Authorize(opts auth.Options) router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+			route := router.FromContext(ctx)
+			if route == nil {
+				return next(ctx, request)
+			}
+
+			identity, err := auth.FromRequest(request, opts)
+			ctx = auth.NewContext(audit.NewContext(ctx, opts.Audit), identity)
+			if err != nil {
+				return auth.Unauthenticated(ctx, err.Error())
+			}
+
+			permission, _ := route.Value(auth.RequiredPermission).(auth.Permission)
+			switch {
+			case identity.Role == "":
+				return auth.Deny(ctx, auth.Resource{}, "principal has no role")
+			case !identity.Role.Valid():
+				return auth.Deny(ctx, auth.Resource{}, fmt.Sprintf("unknown role %q", identity.Role))
+			case permission == "" && identity.Role != auth.RoleAdmin:
+				return auth.Deny(ctx, auth.Resource{}, "route declares no permission; only admins may call it")
+			case permission != "" && !identity.Can(permission):
+				return auth.Deny(ctx, auth.Resource{}, fmt.Sprintf("role %s lacks %s", identity.Role, permission))
+			case identity.Tenant == "":
+				return auth.Deny(ctx, auth.Resource{}, "principal has no tenant")
+			case !tenant.Valid(identity.Tenant):
+				return auth.Deny(ctx, auth.Resource{}, fmt.Sprintf("invalid tenant %q", identity.Tenant))
+			}
+			return next(tenant.NewContext(ctx, identity.Tenant), request)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// CORSConfig configures cross-origin requests
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to call the API; "*" allows any
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// CORSConfigFromConfig returns the CORS settings of the application configuration
+func CORSConfigFromConfig(cfg *config.Config) CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: cfg.CORSAllowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders: cfg.CORSAllowedHeaders,
+		ExposedHeaders: []string{RequestIDHeader, "Content-Range", "Server-Timing"},
+		MaxAge:         cfg.CORSMaxAge,
+	}
+}
+
+// allowOrigin returns the Access-Control-Allow-Origin value for origin, or ""
+func (c CORSConfig) allowOrigin(origin string) string {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// CORS adds CORS headers to responses for allowed origins and answers
+// preflight requests with 204 without calling the handler
+func CORS(cfg CORSConfig) router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+			allowOrigin := cfg.allowOrigin(router.Header(request, "Origin"))
+
+			preflight := router.Method(request) == http.MethodOptions &&
+				router.Header(request, "Access-Control-Request-Method") != ""
+			if preflight {
+				response := events.APIGatewayV2HTTPResponse{StatusCode: http.StatusNoContent}
+				if allowOrigin != "" {
+					setCORSHeaders(&response, allowOrigin)
+					setHeader(&response, "Access-Control-Allow-Methods", strings.Join(cfg.AllowedMethods, ", "))
+					setHeader(&response, "Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
+					setHeader(&response, "Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+				}
+				return response, nil
+			}
+
+			response, err := next(ctx, request)
+			if allowOrigin != "" {
+				setCORSHeaders(&response, allowOrigin)
+				if len(cfg.ExposedHeaders) > 0 {
+					setHeader(&response, "Access-Control-Expose-Headers", strings.Join(cfg.ExposedHeaders, ", "))
+				}
+			}
+			return response, err
+		}
+	}
+}
+
+// setCORSHeaders sets the allowed origin; responses that depend on the
+// request's origin must not be cached for other origins
+func setCORSHeaders(response *events.APIGatewayV2HTTPResponse, allowOrigin string) {
+	setHeader(response, "Access-Control-Allow-Origin", allowOrigin)
+	if allowOrigin != "*" {
+		setHeader(response, "Vary", "Origin")
+	}
+}
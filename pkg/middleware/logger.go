@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Logger logs every request with its route, status and duration, and
+// reports the duration to the client in a Server-Timing header
+func Logger() router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+			start := time.Now()
+			response, err := next(ctx, request)
+			elapsed := time.Since(start)
+
+			route := "-"
+			if r := router.FromContext(ctx); r != nil {
+				route = r.Name
+				if route == "" {
+					route = r.Pattern
+				}
+			}
+			log.Printf("request_id=%s method=%s path=%s route=%s status=%d duration=%s",
+				RequestIDFromContext(ctx), router.Method(request), router.Path(request), route, response.StatusCode, elapsed)
+			if err != nil {
+				log.Printf("request_id=%s error=%v", RequestIDFromContext(ctx), err)
+			}
+
+			setHeader(&response, "Server-Timing", fmt.Sprintf("app;dur=%.1f", float64(elapsed.Microseconds())/1000))
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package middleware provides the cross-cutting behaviour shared by the
+// services' routers: request IDs, logging and timing, panic recovery, CORS,
+// request deadlines and authorization.
+package middleware
+
+import (
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Standard returns the middleware every service runs, outermost first.
+// The request ID is assigned before anything is logged, and panics are
+// recovered inside the logger and CORS so the resulting 500 is logged and
+// readable by browsers. CORS preflights are answered before authorization,
+// as they carry no credentials.
+func Standard(cfg *config.Config) []router.Middleware {
+	return []router.Middleware{
+		RequestID(),
+		Logger(),
+		CORS(CORSConfigFromConfig(cfg)),
+		Recover(),
+		Timeout(cfg.RequestTimeoutMargin, cfg.RequestTimeout),
+		Authorize(auth.OptionsFromConfig(cfg)),
+	}
+}
+
+// setHeader sets a response header, creating the header map if needed
+func setHeader(response *events.APIGatewayV2HTTPResponse, name, value string) {
+	if response.Headers == nil {
+		response.Headers = make(map[string]string)
+	}
+	response.Headers[name] = value
+}
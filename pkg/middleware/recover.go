@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Recover turns a panicking handler into a 500 response instead of a
+// failed invocation. The panic and its stack trace are logged.
+func Recover() router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (response events.APIGatewayV2HTTPResponse, err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					log.Printf("request_id=%s panic=%v\n%s", RequestIDFromContext(ctx), recovered, debug.Stack())
+					response, err = respond.Error(ctx, http.StatusInternalServerError, "Internal server error")
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
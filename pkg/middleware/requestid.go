@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+
+	"security-questionnaire/pkg/requestid"
+	"security-questionnaire/pkg/router"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the response header carrying the request ID
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID stores the API Gateway request ID in the context and returns it
+// in the X-Request-Id response header, so client reports can be matched
+// with the logs. Requests without one, e.g. in tests, get a random ID.
+func RequestID() router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+			id := request.RequestContext.RequestID
+			if id == "" {
+				id = uuid.New().String()
+				request.RequestContext.RequestID = id
+			}
+
+			response, err := next(requestid.NewContext(ctx, id), request)
+			setHeader(&response, RequestIDHeader, id)
+			return response, err
+		}
+	}
+}
+
+// RequestIDFromContext returns the ID of the request being handled, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
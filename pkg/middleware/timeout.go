@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"security-questionnaire/pkg/router"
+	"security-questionnaire/pkg/timeout"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Timeout bounds each request with timeout.WithDeadline, so handlers stop
+// margin before the Lambda deadline, or after fallback outside Lambda
+func Timeout(margin, fallback time.Duration) router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+			ctx, cancel := timeout.WithDeadline(ctx, margin, fallback)
+			defer cancel()
+			return next(ctx, request)
+		}
+	}
+}
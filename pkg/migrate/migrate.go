@@ -0,0 +1,249 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationsTable records which migrations have been applied
+const migrationsTable = "schema_migrations"
+
+// lockKey identifies the advisory lock held while migrating
+const lockKey int64 = 0x6d6967726174 // "migrat"
+
+// fileNamePattern matches migration files such as 0001_create_documents.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change with its rollback
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration has been applied
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	// Missing is set for applied migrations that are not known to this binary
+	Missing bool `json:"missing,omitempty"`
+}
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	Version   int64     `gorm:"column:version;primaryKey"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// TableName specifies the table name for applied migrations
+func (appliedMigration) TableName() string {
+	return migrationsTable
+}
+
+// Migrator applies and rolls back migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// Load reads the migrations in the root of fsys, ordered by version.
+// Every version needs an up file; down files are optional.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// New creates a migrator for the migrations in fsys
+func New(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in order and returns the applied ones.
+// Each migration runs in its own transaction, so a failure keeps the
+// migrations before it.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for {
+		var next *Migration
+		err := m.locked(ctx, func(tx *gorm.DB, done map[int64]appliedMigration) error {
+			for i := range m.migrations {
+				if _, ok := done[m.migrations[i].Version]; !ok {
+					next = &m.migrations[i]
+					break
+				}
+			}
+			if next == nil {
+				return nil
+			}
+
+			if err := tx.Exec(next.Up).Error; err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", next.Version, next.Name, err)
+			}
+			return tx.Create(&appliedMigration{Version: next.Version, Name: next.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, err
+		}
+		if next == nil {
+			return applied, nil
+		}
+		applied = append(applied, *next)
+	}
+}
+
+// Down rolls back the most recently applied migrations, at most steps of them,
+// and returns the rolled back ones
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var rolledBack []Migration
+	for i := 0; i < steps; i++ {
+		var last *Migration
+		err := m.locked(ctx, func(tx *gorm.DB, done map[int64]appliedMigration) error {
+			for j := len(m.migrations) - 1; j >= 0; j-- {
+				if _, ok := done[m.migrations[j].Version]; ok {
+					last = &m.migrations[j]
+					break
+				}
+			}
+			if last == nil {
+				return nil
+			}
+			if last.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be rolled back", last.Version, last.Name)
+			}
+
+			if err := tx.Exec(last.Down).Error; err != nil {
+				return fmt.Errorf("rollback of %d_%s failed: %w", last.Version, last.Name, err)
+			}
+			return tx.Delete(&appliedMigration{}, "version = ?", last.Version).Error
+		})
+		if err != nil {
+			return rolledBack, err
+		}
+		if last == nil {
+			break
+		}
+		rolledBack = append(rolledBack, *last)
+	}
+	return rolledBack, nil
+}
+
+// Status lists every known migration and whether it has been applied,
+// followed by applied migrations this binary does not know about
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(tx *gorm.DB, done map[int64]appliedMigration) error {
+		known := make(map[int64]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := done[migration.Version]; ok {
+				appliedAt := row.AppliedAt
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		var missing []Status
+		for version, row := range done {
+			if !known[version] {
+				appliedAt := row.AppliedAt
+				missing = append(missing, Status{Version: version, Name: row.Name, AppliedAt: &appliedAt, Missing: true})
+			}
+		}
+		sort.Slice(missing, func(i, j int) bool { return missing[i].Version < missing[j].Version })
+		statuses = append(statuses, missing...)
+		return nil
+	})
+	return statuses, err
+}
+
+// locked runs fn in a transaction holding the migration lock, so concurrent
+// migrators wait for each other and always see the migrations applied by the
+// one before them. The lock is a transaction-level advisory lock, which also
+// covers creating the schema_migrations table on first use.
+func (m *Migrator) locked(ctx context.Context, fn func(tx *gorm.DB, done map[int64]appliedMigration) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		createTable := `CREATE TABLE IF NOT EXISTS ` + migrationsTable + ` (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`
+		if err := tx.Exec(createTable).Error; err != nil {
+			return fmt.Errorf("failed to create %s table: %w", migrationsTable, err)
+		}
+
+		var rows []appliedMigration
+		if err := tx.Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		done := make(map[int64]appliedMigration, len(rows))
+		for _, row := range rows {
+			done[row.Version] = row
+		}
+
+		return fn(tx, done)
+	})
+}
@@ -6,10 +6,16 @@ import (
 	"gorm.io/gorm"
 )
 
-// BaseModel contains common fields for all models
+// BaseModel contains common fields for all models. Its records belong to a
+// tenant: pkg/database fills in TenantID on create and restricts every query
+// to the tenant of the request.
 type BaseModel struct {
 	ID        string         `gorm:"column:id;primaryKey;type:uuid;default:uuid_generate_v4()" json:"id"`
+	TenantID  string         `gorm:"column:tenant_id;not null;index" json:"tenant_id"`
 	CreatedAt time.Time      `gorm:"column:created_at" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deleted_at,omitempty"`
 }
+
+// TenantScoped marks the models embedding BaseModel as tenant-scoped
+func (BaseModel) TenantScoped() {}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a map stored in a jsonb column
+type JSONMap map[string]interface{}
+
+// Value implements driver.Valuer so the map is written as JSON
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON map: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner so jsonb values are read back into the map
+func (m *JSONMap) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for JSON map: %T", value)
+	}
+
+	return json.Unmarshal(data, m)
+}
@@ -0,0 +1,234 @@
+// Package query parses the query string parameters of list endpoints into
+// pkg/database options. Each endpoint whitelists its parameters in a Spec;
+// invalid or unknown parameters are reported as *Error, which handlers answer
+// with 400.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"security-questionnaire/pkg/database"
+)
+
+// Pagination and sort parameters accepted by every list endpoint
+const (
+	ParamLimit        = "limit"
+	ParamCursor       = "cursor"
+	ParamIncludeTotal = "include_total"
+	ParamSort         = "sort"
+)
+
+// Error describes an invalid query string parameter
+type Error struct {
+	Param   string
+	Message string
+}
+
+// Error returns the message prefixed with the parameter name
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Param, e.Message)
+}
+
+// Type is the type of a filter parameter's value
+type Type int
+
+// Filter parameter types
+const (
+	// String values are used as is
+	String Type = iota
+	// Int values are whole numbers
+	Int
+	// Time values are RFC 3339 timestamps or YYYY-MM-DD dates
+	Time
+	// UnixTime values are written like Time and compared as Unix seconds
+	UnixTime
+)
+
+// Param whitelists a filter parameter. OpIn parameters take a
+// comma-separated list of values.
+type Param struct {
+	Name     string
+	Column   string
+	Operator database.Operator
+	Type     Type
+	// Values restricts the accepted values, if set
+	Values []string
+}
+
+// Spec lists the parameters a list endpoint accepts
+type Spec struct {
+	Params []Param
+	// Sortable lists the columns accepted by the sort parameter. They must be
+	// indexed and not null; prefix a column with "-" to sort descending.
+	Sortable []string
+}
+
+// Parse turns pagination, filter and sort parameters into page options.
+// Parameters that are not in spec are rejected.
+func Parse(params map[string]string, spec Spec) (database.PageOptions, error) {
+	opts, err := parsePage(params)
+	if err != nil {
+		return opts, err
+	}
+
+	byName := make(map[string]Param, len(spec.Params))
+	for _, p := range spec.Params {
+		byName[p.Name] = p
+	}
+
+	// Check parameters in a fixed order so errors are deterministic
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := params[name]
+		switch name {
+		case ParamLimit, ParamCursor, ParamIncludeTotal:
+			continue
+		case ParamSort:
+			if opts.Sort, err = parseSort(value, spec.Sortable); err != nil {
+				return opts, err
+			}
+			continue
+		}
+
+		p, ok := byName[name]
+		if !ok {
+			return opts, &Error{Param: name, Message: fmt.Sprintf("unknown parameter; accepted filters are %s", spec.names())}
+		}
+		filter, err := p.filter(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = append(opts.Filters, filter)
+	}
+
+	return opts, nil
+}
+
+// parsePage parses limit, cursor and include_total. A limit above
+// database.MaxPageSize is clamped to it.
+func parsePage(params map[string]string) (database.PageOptions, error) {
+	opts := database.PageOptions{
+		Limit:  database.DefaultPageSize,
+		Cursor: params[ParamCursor],
+	}
+
+	if value := params[ParamLimit]; value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return opts, &Error{Param: ParamLimit, Message: "must be a positive integer"}
+		}
+		if limit > database.MaxPageSize {
+			limit = database.MaxPageSize
+		}
+		opts.Limit = limit
+	}
+
+	if value := params[ParamIncludeTotal]; value != "" {
+		withTotal, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, &Error{Param: ParamIncludeTotal, Message: "must be true or false"}
+		}
+		opts.WithTotal = withTotal
+	}
+
+	return opts, nil
+}
+
+// parseSort parses "column" or "-column" against the sortable columns
+func parseSort(value string, sortable []string) (database.Sort, error) {
+	s := database.Asc(strings.TrimPrefix(value, "-"))
+	s.Desc = strings.HasPrefix(value, "-")
+
+	for _, column := range sortable {
+		if column == s.Column {
+			return s, nil
+		}
+	}
+	return s, &Error{Param: ParamSort, Message: fmt.Sprintf("must be one of %s, optionally prefixed with -", strings.Join(sortable, ", "))}
+}
+
+// filter converts a parameter value into a database filter
+func (p Param) filter(raw string) (database.Filter, error) {
+	if raw == "" {
+		return database.Filter{}, &Error{Param: p.Name, Message: "must not be empty"}
+	}
+
+	if p.Operator == database.OpIn {
+		var values []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			value, err := p.convert(strings.TrimSpace(part))
+			if err != nil {
+				return database.Filter{}, err
+			}
+			values = append(values, value)
+		}
+		return database.Filter{Column: p.Column, Operator: p.Operator, Value: values}, nil
+	}
+
+	value, err := p.convert(raw)
+	if err != nil {
+		return database.Filter{}, err
+	}
+	return database.Filter{Column: p.Column, Operator: p.Operator, Value: value}, nil
+}
+
+// convert parses a single value according to the parameter's type
+func (p Param) convert(raw string) (interface{}, error) {
+	if len(p.Values) > 0 {
+		for _, allowed := range p.Values {
+			if raw == allowed {
+				return raw, nil
+			}
+		}
+		return nil, &Error{Param: p.Name, Message: fmt.Sprintf("must be one of %s", strings.Join(p.Values, ", "))}
+	}
+
+	switch p.Type {
+	case Int:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, &Error{Param: p.Name, Message: "must be an integer"}
+		}
+		return n, nil
+	case Time, UnixTime:
+		t, err := parseTime(raw)
+		if err != nil {
+			return nil, &Error{Param: p.Name, Message: "must be an RFC 3339 timestamp or a YYYY-MM-DD date"}
+		}
+		if p.Type == UnixTime {
+			return t.Unix(), nil
+		}
+		return t, nil
+	default:
+		return raw, nil
+	}
+}
+
+// parseTime parses an RFC 3339 timestamp or a date (midnight UTC)
+func parseTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
+
+// names lists the accepted filter parameter names
+func (s Spec) names() string {
+	names := make([]string, len(s.Params))
+	for i, p := range s.Params {
+		names[i] = p.Name
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"security-questionnaire/pkg/database"
+)
+
+var testSpec = Spec{
+	Params: []Param{
+		{Name: "status", Column: "status", Operator: database.OpIn, Values: []string{"draft", "submitted"}},
+		{Name: "file_name", Column: "file_name", Operator: database.OpContains},
+		{Name: "min_size", Column: "file_size", Operator: database.OpGte, Type: Int},
+		{Name: "created_after", Column: "created_at", Operator: database.OpGte, Type: Time},
+		{Name: "completed_after", Column: "completed_at", Operator: database.OpGte, Type: UnixTime},
+	},
+	Sortable: []string{"created_at", "file_name"},
+}
+
+func TestParse(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   database.PageOptions
+	}{
+		{
+			name: "defaults",
+			want: database.PageOptions{Limit: database.DefaultPageSize},
+		},
+		{
+			name:   "pagination",
+			params: map[string]string{"limit": "25", "cursor": "abc", "include_total": "true"},
+			want:   database.PageOptions{Limit: 25, Cursor: "abc", WithTotal: true},
+		},
+		{
+			name:   "limit clamped",
+			params: map[string]string{"limit": "1000"},
+			want:   database.PageOptions{Limit: database.MaxPageSize},
+		},
+		{
+			name:   "descending sort",
+			params: map[string]string{"sort": "-file_name"},
+			want:   database.PageOptions{Limit: database.DefaultPageSize, Sort: database.Desc("file_name")},
+		},
+		{
+			name:   "ascending sort",
+			params: map[string]string{"sort": "created_at"},
+			want:   database.PageOptions{Limit: database.DefaultPageSize, Sort: database.Asc("created_at")},
+		},
+		{
+			name:   "in filter",
+			params: map[string]string{"status": "draft, submitted"},
+			want: database.PageOptions{Limit: database.DefaultPageSize, Filters: []database.Filter{
+				{Column: "status", Operator: database.OpIn, Value: []interface{}{"draft", "submitted"}},
+			}},
+		},
+		{
+			name:   "typed filters in name order",
+			params: map[string]string{"min_size": "1024", "file_name": "report", "created_after": "2024-03-01", "completed_after": "2024-03-01T00:00:00Z"},
+			want: database.PageOptions{Limit: database.DefaultPageSize, Filters: []database.Filter{
+				{Column: "completed_at", Operator: database.OpGte, Value: day.Unix()},
+				{Column: "created_at", Operator: database.OpGte, Value: day},
+				{Column: "file_name", Operator: database.OpContains, Value: "report"},
+				{Column: "file_size", Operator: database.OpGte, Value: int64(1024)},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.params, testSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse(%v) = %+v, want %+v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    map[string]string
+		wantParam string
+	}{
+		{name: "unknown parameter", params: map[string]string{"owner_id": "x"}, wantParam: "owner_id"},
+		{name: "sort not whitelisted", params: map[string]string{"sort": "file_size"}, wantParam: ParamSort},
+		{name: "descending sort not whitelisted", params: map[string]string{"sort": "-owner_id"}, wantParam: ParamSort},
+		{name: "zero limit", params: map[string]string{"limit": "0"}, wantParam: ParamLimit},
+		{name: "limit not a number", params: map[string]string{"limit": "ten"}, wantParam: ParamLimit},
+		{name: "include_total not a bool", params: map[string]string{"include_total": "maybe"}, wantParam: ParamIncludeTotal},
+		{name: "value not allowed", params: map[string]string{"status": "draft,archived"}, wantParam: "status"},
+		{name: "empty filter", params: map[string]string{"file_name": ""}, wantParam: "file_name"},
+		{name: "not an integer", params: map[string]string{"min_size": "1kb"}, wantParam: "min_size"},
+		{name: "not a time", params: map[string]string{"created_after": "yesterday"}, wantParam: "created_after"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.params, testSpec)
+			var queryErr *Error
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Parse(%v) = %v, want a *query.Error", tt.params, err)
+			}
+			if queryErr.Param != tt.wantParam {
+				t.Fatalf("error is about %q, want %q", queryErr.Param, tt.wantParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Package requestid carries the ID of the request being handled in its
+// context, so responses and logs can refer to it without importing the
+// middleware that assigns it.
+package requestid
+
+import "context"
+
+// key is the context key of the request ID
+type key struct{}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the ID of the request being handled, or ""
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
@@ -0,0 +1,146 @@
+// Package respond builds the JSON API Gateway responses shared by all
+// services, so every endpoint answers with the same envelope. Errors are
+// RFC 7807 problem details carrying a stable code and the request ID.
+package respond
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/query"
+	"security-questionnaire/pkg/requestid"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ProblemContentType is the media type of error responses
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the code to form the problem type URI
+const problemTypeBase = "urn:security-questionnaire:problem:"
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Code is the stable, machine-readable error code
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Success creates a successful API Gateway V2 response
+func Success(statusCode int, data interface{}) (events.APIGatewayV2HTTPResponse, error) {
+	return JSON(statusCode, data)
+}
+
+// Error creates an error response with the generic code of statusCode
+func Error(ctx context.Context, statusCode int, detail string) (events.APIGatewayV2HTTPResponse, error) {
+	return Fail(ctx, statusCode, apierror.CodeForStatus(statusCode), detail)
+}
+
+// Fail creates an error response with a specific code
+func Fail(ctx context.Context, statusCode int, code, detail string) (events.APIGatewayV2HTTPResponse, error) {
+	return ProblemResponse(ctx, Problem{Status: statusCode, Code: code, Detail: detail})
+}
+
+// ErrorFor creates an error response whose status code is derived from err:
+// 400 for invalid query parameters, 403 for files of another tenant, 404
+// when resource does not exist, 409 on conflicts, 503 when the database is
+// unavailable or busy, 504 on timeouts and 500 otherwise. Server errors are
+// logged; only action is returned.
+func ErrorFor(ctx context.Context, err error, resource, action string) (events.APIGatewayV2HTTPResponse, error) {
+	statusCode, code, detail := apierror.Describe(err, resource, action)
+	if statusCode >= 500 {
+		logError(ctx, statusCode, code, err)
+	}
+
+	problem := Problem{Status: statusCode, Code: code, Detail: detail}
+	var queryErr *query.Error
+	if errors.As(err, &queryErr) {
+		problem.Errors = []FieldError{{Field: queryErr.Param, Code: apierror.FieldInvalid, Message: queryErr.Message}}
+	}
+	return ProblemResponse(ctx, problem)
+}
+
+// Internal creates a 500 response for an unexpected error. err is logged
+// with the request ID; the client only receives detail.
+func Internal(ctx context.Context, err error, detail string) (events.APIGatewayV2HTTPResponse, error) {
+	logError(ctx, http.StatusInternalServerError, apierror.CodeInternal, err)
+	return Fail(ctx, http.StatusInternalServerError, apierror.CodeInternal, detail)
+}
+
+// InvalidBody creates a 400 response for a body that could not be decoded
+func InvalidBody(ctx context.Context) (events.APIGatewayV2HTTPResponse, error) {
+	return Fail(ctx, http.StatusBadRequest, apierror.CodeInvalidBody, "Invalid request body")
+}
+
+// InvalidFields creates a 400 response listing the malformed or missing fields
+func InvalidFields(ctx context.Context, detail string, errs ...FieldError) (events.APIGatewayV2HTTPResponse, error) {
+	return ProblemResponse(ctx, Problem{Status: http.StatusBadRequest, Code: apierror.CodeBadRequest, Detail: detail, Errors: errs})
+}
+
+// Required creates a 400 response for missing fields
+func Required(ctx context.Context, detail string, fields ...string) (events.APIGatewayV2HTTPResponse, error) {
+	errs := make([]FieldError, len(fields))
+	for i, field := range fields {
+		errs[i] = FieldError{Field: field, Code: apierror.FieldRequired, Message: field + " is required"}
+	}
+	return InvalidFields(ctx, detail, errs...)
+}
+
+// ValidationError creates a 422 response listing every failing field
+func ValidationError(ctx context.Context, detail string, errs []FieldError) (events.APIGatewayV2HTTPResponse, error) {
+	return ProblemResponse(ctx, Problem{Status: http.StatusUnprocessableEntity, Code: apierror.CodeValidationFailed, Detail: detail, Errors: errs})
+}
+
+// ProblemResponse creates an application/problem+json response, filling in
+// the type, title and request ID of problem
+func ProblemResponse(ctx context.Context, problem Problem) (events.APIGatewayV2HTTPResponse, error) {
+	if problem.Code == "" {
+		problem.Code = apierror.CodeForStatus(problem.Status)
+	}
+	if problem.Type == "" {
+		problem.Type = problemTypeBase + problem.Code
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.RequestID == "" {
+		problem.RequestID = requestid.FromContext(ctx)
+	}
+
+	response, err := JSON(problem.Status, problem)
+	response.Headers["Content-Type"] = ProblemContentType
+	return response, err
+}
+
+// JSON creates a response with body marshalled as JSON
+func JSON(statusCode int, body interface{}) (events.APIGatewayV2HTTPResponse, error) {
+	data, _ := json.Marshal(body)
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode: statusCode,
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Body: string(data),
+	}, nil
+}
+
+// logError logs the internal details of an error response
+func logError(ctx context.Context, statusCode int, code string, err error) {
+	log.Printf("request_id=%s status=%d code=%s error=%v", requestid.FromContext(ctx), statusCode, code, err)
+}
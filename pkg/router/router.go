@@ -0,0 +1,291 @@
+// Package router dispatches API Gateway HTTP API (payload v2) requests to
+// handlers by method and path pattern. Patterns are matched against the
+// path without the stage prefix, so the same routes serve every stage.
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/respond"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Handler handles an API Gateway HTTP API request
+type Handler func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error)
+
+// Middleware wraps a handler with cross-cutting behaviour
+type Middleware func(next Handler) Handler
+
+// Route is a registered method and path pattern. Pattern segments written
+// as {name} match any single segment and are passed to the handler in
+// request.PathParameters.
+type Route struct {
+	Method  string
+	Pattern string
+	// Name identifies the route in logs, e.g. "documents.read"
+	Name string
+	// Meta holds route-level settings read by middleware
+	Meta map[string]interface{}
+
+	handler  Handler
+	segments []string
+}
+
+// Named sets the route name
+func (r *Route) Named(name string) *Route {
+	r.Name = name
+	return r
+}
+
+// With stores a metadata value on the route
+func (r *Route) With(key string, value interface{}) *Route {
+	if r.Meta == nil {
+		r.Meta = make(map[string]interface{})
+	}
+	r.Meta[key] = value
+	return r
+}
+
+// Value returns a metadata value of the route, or nil
+func (r *Route) Value(key string) interface{} {
+	return r.Meta[key]
+}
+
+// match reports whether the route's pattern matches the path segments and
+// returns the path parameters and the number of literal segments matched
+func (r *Route) match(segments []string) (map[string]string, int, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, 0, false
+	}
+
+	params := make(map[string]string)
+	literals := 0
+	for i, want := range r.segments {
+		if name, ok := paramName(want); ok {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil || value == "" {
+				return nil, 0, false
+			}
+			params[name] = value
+			continue
+		}
+		if segments[i] != want {
+			return nil, 0, false
+		}
+		literals++
+	}
+	return params, literals, true
+}
+
+// Router holds the routes of a service and the middleware run around them
+type Router struct {
+	routes     []*Route
+	middleware []Middleware
+}
+
+// New creates an empty router
+func New() *Router {
+	return &Router{}
+}
+
+// Handle registers a handler for a method and path pattern
+func (r *Router) Handle(method, pattern string, handler Handler) *Route {
+	route := &Route{
+		Method:   method,
+		Pattern:  pattern,
+		handler:  handler,
+		segments: split(pattern),
+	}
+	r.routes = append(r.routes, route)
+	return route
+}
+
+// GET registers a handler for GET requests
+func (r *Router) GET(pattern string, handler Handler) *Route {
+	return r.Handle(http.MethodGet, pattern, handler)
+}
+
+// POST registers a handler for POST requests
+func (r *Router) POST(pattern string, handler Handler) *Route {
+	return r.Handle(http.MethodPost, pattern, handler)
+}
+
+// PUT registers a handler for PUT requests
+func (r *Router) PUT(pattern string, handler Handler) *Route {
+	return r.Handle(http.MethodPut, pattern, handler)
+}
+
+// DELETE registers a handler for DELETE requests
+func (r *Router) DELETE(pattern string, handler Handler) *Route {
+	return r.Handle(http.MethodDelete, pattern, handler)
+}
+
+// Use appends middleware. Middleware runs in the order it was added, around
+// every request, including those answered with 404 or 405, so it can handle
+// requests such as CORS preflights that match no route.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Routes returns the registered routes in registration order
+func (r *Router) Routes() []*Route {
+	return r.routes
+}
+
+// Serve dispatches a request to the route matching its method and path.
+// When several patterns match, the one with the most literal segments wins,
+// so /results/recompute takes precedence over /results/{id}. Paths that
+// match only with another method get 405, unknown paths get 404.
+func (r *Router) Serve(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	route, params, allowed := r.lookup(Method(request), split(Path(request)))
+
+	var handler Handler
+	switch {
+	case route != nil:
+		handler = route.handler
+		ctx = context.WithValue(ctx, routeKey{}, route)
+
+		pathParams := make(map[string]string, len(request.PathParameters)+len(params))
+		for name, value := range request.PathParameters {
+			pathParams[name] = value
+		}
+		for name, value := range params {
+			pathParams[name] = value
+		}
+		request.PathParameters = pathParams
+	case len(allowed) > 0:
+		handler = methodNotAllowed(allowed)
+	default:
+		handler = notFound
+	}
+
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	return handler(ctx, request)
+}
+
+// notFound answers requests for unknown paths
+func notFound(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return respond.Fail(ctx, http.StatusNotFound, apierror.CodeRouteNotFound, "Route not found")
+}
+
+// methodNotAllowed answers requests for known paths with another method
+func methodNotAllowed(allowed []string) Handler {
+	return func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+		response, err := respond.Error(ctx, http.StatusMethodNotAllowed, "Method not allowed")
+		response.Headers["Allow"] = strings.Join(allowed, ", ")
+		return response, err
+	}
+}
+
+// lookup finds the best route for method and path. Without a match it
+// returns the methods registered for the path, if any.
+func (r *Router) lookup(method string, segments []string) (*Route, map[string]string, []string) {
+	var (
+		best         *Route
+		bestParams   map[string]string
+		bestLiterals = -1
+		allowed      []string
+	)
+
+	for _, route := range r.routes {
+		params, literals, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		if route.Method != method {
+			allowed = appendUnique(allowed, route.Method)
+			continue
+		}
+		if literals > bestLiterals {
+			best, bestParams, bestLiterals = route, params, literals
+		}
+	}
+
+	sort.Strings(allowed)
+	return best, bestParams, allowed
+}
+
+// routeKey is the context key of the matched route
+type routeKey struct{}
+
+// FromContext returns the route matched for the request, or nil
+func FromContext(ctx context.Context) *Route {
+	route, _ := ctx.Value(routeKey{}).(*Route)
+	return route
+}
+
+// Method returns the HTTP method of a request
+func Method(request events.APIGatewayV2HTTPRequest) string {
+	return strings.ToUpper(request.RequestContext.HTTP.Method)
+}
+
+// Path returns the request path without the stage prefix. API Gateway
+// includes the stage in the path for every stage except $default.
+func Path(request events.APIGatewayV2HTTPRequest) string {
+	path := request.RawPath
+	if path == "" {
+		path = request.RequestContext.HTTP.Path
+	}
+
+	if stage := request.RequestContext.Stage; stage != "" && stage != "$default" {
+		prefix := "/" + stage
+		if path == prefix {
+			return "/"
+		}
+		if strings.HasPrefix(path, prefix+"/") {
+			path = strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// Header returns a request header. API Gateway lowercases header names, but
+// the lookup is case-insensitive so requests built by hand work as well.
+func Header(request events.APIGatewayV2HTTPRequest, name string) string {
+	if value, ok := request.Headers[strings.ToLower(name)]; ok {
+		return value
+	}
+	for key, value := range request.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// split splits a path into its segments, ignoring empty ones
+func split(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// paramName returns the name of a {name} pattern segment
+func paramName(segment string) (string, bool) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// appendUnique appends value unless values already contains it
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// metaSuffix marks the sidecar file holding a local file's content type
+const metaSuffix = ".meta.json"
+
+// LocalStorage stores files on the local filesystem and serves them through
+// signed URLs handled by NewSignedURLHandler. It is intended for local development.
+type LocalStorage struct {
+	root   string
+	signer *URLSigner
+}
+
+// localMeta is the content of a sidecar metadata file
+type localMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+// NewLocalStorage creates a filesystem backend rooted at dir
+func NewLocalStorage(dir string, signer *URLSigner) (*LocalStorage, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage directory: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStorage{root: root, signer: signer}, nil
+}
+
+// Signer returns the signer used for the backend's URLs
+func (s *LocalStorage) Signer() *URLSigner {
+	return s.signer
+}
+
+// path maps a key to a file path, refusing keys that escape the root
+func (s *LocalStorage) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) || strings.HasSuffix(key, metaSuffix) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return path, nil
+}
+
+// UploadFile stores a file under a newly generated key and returns the key and URL
+func (s *LocalStorage) UploadFile(ctx context.Context, data UploadFileData) (string, string, error) {
+	return uploadFile(ctx, s, data)
+}
+
+// UploadStream stores everything read from body under key
+func (s *LocalStorage) UploadStream(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see partial content
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx, body}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	meta, _ := json.Marshal(localMeta{ContentType: contentType})
+	if err := os.WriteFile(path+metaSuffix, meta, 0o644); err != nil {
+		return fmt.Errorf("failed to write file metadata: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+	return nil
+}
+
+// GetFile returns the whole content of a file
+func (s *LocalStorage) GetFile(ctx context.Context, key string) ([]byte, error) {
+	return getFile(ctx, s, key)
+}
+
+// Download streams a file into w and returns the number of bytes written
+func (s *LocalStorage) Download(ctx context.Context, key string, w io.Writer) (int64, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return 0, err
+	}
+	file, err := s.open(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	return io.Copy(w, contextReader{ctx, file})
+}
+
+// GetRange opens the inclusive byte range [start, end] of a file
+func (s *LocalStorage) GetRange(ctx context.Context, key string, start, end int64) (io.ReadCloser, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return nil, err
+	}
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid byte range %d-%d", start, end)
+	}
+
+	file, err := s.open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{contextReader{ctx, io.NewSectionReader(file, start, end-start+1)}, file}, nil
+}
+
+// GetFileURL returns a signed download URL valid for expiration
+func (s *LocalStorage) GetFileURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign("GET", key, expiration), nil
+}
+
+// GetUploadURL returns a signed HTTP PUT URL valid for expiration
+func (s *LocalStorage) GetUploadURL(ctx context.Context, key, contentType string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign("PUT", key, expiration), nil
+}
+
+// HeadFile returns the size and content type of a file
+func (s *LocalStorage) HeadFile(ctx context.Context, key string) (*FileInfo, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to get file info: %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	var meta localMeta
+	if data, err := os.ReadFile(path + metaSuffix); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+
+	return &FileInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  meta.ContentType,
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+// ListFiles returns the files whose key starts with prefix
+func (s *LocalStorage) ListFiles(ctx context.Context, prefix string) ([]FileInfo, error) {
+	if err := checkTenantKey(ctx, prefix); err != nil {
+		return nil, err
+	}
+	var files []FileInfo
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(path, metaSuffix) || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := s.HeadFile(ctx, key)
+		if err != nil {
+			return err
+		}
+		files = append(files, *info)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
+
+// DeleteFile removes a file
+func (s *LocalStorage) DeleteFile(ctx context.Context, key string) error {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return err
+	}
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	_ = os.Remove(path + metaSuffix)
+	return nil
+}
+
+// FileURL returns the unsigned location of a file
+func (s *LocalStorage) FileURL(key string) string {
+	return s.signer.URL(key)
+}
+
+// open opens a stored file for reading
+func (s *LocalStorage) open(ctx context.Context, key string) (*os.File, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open file: %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStorage keeps files in memory. It is intended for tests.
+type MemoryStorage struct {
+	mu     sync.RWMutex
+	files  map[string]memoryFile
+	signer *URLSigner
+}
+
+// memoryFile is a file held by MemoryStorage
+type memoryFile struct {
+	data        []byte
+	contentType string
+	modified    time.Time
+}
+
+var (
+	memoryOnce   sync.Once
+	memoryShared *MemoryStorage
+	memoryErr    error
+)
+
+// NewMemoryStorage creates an empty in-memory backend
+func NewMemoryStorage(signer *URLSigner) *MemoryStorage {
+	return &MemoryStorage{files: make(map[string]memoryFile), signer: signer}
+}
+
+// sharedMemoryStorage returns the process-wide in-memory backend, so that
+// files survive across requests that each call New
+func sharedMemoryStorage(baseURL, signingKey string) (*MemoryStorage, error) {
+	memoryOnce.Do(func() {
+		signer, err := NewURLSigner(baseURL, signingKey)
+		if err != nil {
+			memoryErr = err
+			return
+		}
+		memoryShared = NewMemoryStorage(signer)
+	})
+	return memoryShared, memoryErr
+}
+
+// Signer returns the signer used for the backend's URLs
+func (s *MemoryStorage) Signer() *URLSigner {
+	return s.signer
+}
+
+// UploadFile stores a file under a newly generated key and returns the key and URL
+func (s *MemoryStorage) UploadFile(ctx context.Context, data UploadFileData) (string, string, error) {
+	return uploadFile(ctx, s, data)
+}
+
+// UploadStream stores everything read from body under key
+func (s *MemoryStorage) UploadStream(ctx context.Context, key string, body io.Reader, contentType string) error {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(contextReader{ctx, body})
+	if err != nil {
+		return fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[key] = memoryFile{data: data, contentType: contentType, modified: time.Now()}
+	return nil
+}
+
+// GetFile returns the whole content of a file
+func (s *MemoryStorage) GetFile(ctx context.Context, key string) ([]byte, error) {
+	return getFile(ctx, s, key)
+}
+
+// Download streams a file into w and returns the number of bytes written
+func (s *MemoryStorage) Download(ctx context.Context, key string, w io.Writer) (int64, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return 0, err
+	}
+	file, err := s.get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(w, contextReader{ctx, bytes.NewReader(file.data)})
+}
+
+// GetRange opens the inclusive byte range [start, end] of a file
+func (s *MemoryStorage) GetRange(ctx context.Context, key string, start, end int64) (io.ReadCloser, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return nil, err
+	}
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid byte range %d-%d", start, end)
+	}
+
+	file, err := s.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	section := io.NewSectionReader(bytes.NewReader(file.data), start, end-start+1)
+	return io.NopCloser(contextReader{ctx, section}), nil
+}
+
+// GetFileURL returns a signed download URL valid for expiration
+func (s *MemoryStorage) GetFileURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign("GET", key, expiration), nil
+}
+
+// GetUploadURL returns a signed HTTP PUT URL valid for expiration
+func (s *MemoryStorage) GetUploadURL(ctx context.Context, key, contentType string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign("PUT", key, expiration), nil
+}
+
+// HeadFile returns the size and content type of a file
+func (s *MemoryStorage) HeadFile(ctx context.Context, key string) (*FileInfo, error) {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return nil, err
+	}
+	file, err := s.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		Key:          key,
+		Size:         int64(len(file.data)),
+		ContentType:  file.contentType,
+		LastModified: file.modified,
+	}, nil
+}
+
+// ListFiles returns the files whose key starts with prefix, ordered by key
+func (s *MemoryStorage) ListFiles(ctx context.Context, prefix string) ([]FileInfo, error) {
+	if err := checkTenantKey(ctx, prefix); err != nil {
+		return nil, err
+	}
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var files []FileInfo
+	for key, file := range s.files {
+		if strings.HasPrefix(key, prefix) {
+			files = append(files, FileInfo{
+				Key:          key,
+				Size:         int64(len(file.data)),
+				ContentType:  file.contentType,
+				LastModified: file.modified,
+			})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Key < files[j].Key })
+	return files, nil
+}
+
+// DeleteFile removes a file
+func (s *MemoryStorage) DeleteFile(ctx context.Context, key string) error {
+	if err := checkTenantKey(ctx, key); err != nil {
+		return err
+	}
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, key)
+	return nil
+}
+
+// FileURL returns the unsigned location of a file
+func (s *MemoryStorage) FileURL(key string) string {
+	return s.signer.URL(key)
+}
+
+// get returns a stored file
+func (s *MemoryStorage) get(ctx context.Context, key string) (memoryFile, error) {
+	if err := checkContext(ctx); err != nil {
+		return memoryFile{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	file, ok := s.files[key]
+	if !ok {
+		return memoryFile{}, fmt.Errorf("failed to get file %q: %w", key, ErrNotFound)
+	}
+	return file, nil
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// MinPartSize is the smallest part S3 accepts, except for the last part (5 MB)
+	MinPartSize int64 = 5 * 1024 * 1024
+	// MaxParts is the largest number of parts in a multipart upload
+	MaxParts int64 = 10000
+	// MaxObjectSize is the largest object a multipart upload can assemble (5 TB)
+	MaxObjectSize int64 = 5 * 1024 * 1024 * 1024 * 1024
+)
+
+// UploadedPart describes a part stored in an in-progress multipart upload
+type UploadedPart struct {
+	PartNumber int64  `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// PartSize returns the part size to use for a file so it fits in MaxParts parts.
+// Sizes are rounded up to whole megabytes. fileSize must not exceed MaxObjectSize.
+func PartSize(fileSize int64) int64 {
+	const mb = 1024 * 1024
+	size := fileSize / MaxParts
+	if fileSize%MaxParts != 0 {
+		size++
+	}
+	size = (size + mb - 1) / mb * mb
+	if size < MinPartSize {
+		return MinPartSize
+	}
+	return size
+}
+
+// PartCount returns the number of parts a file of fileSize is split into
+func PartCount(fileSize, partSize int64) int64 {
+	return (fileSize + partSize - 1) / partSize
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID
+func (s *S3Service) CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return "", err
+	}
+	out, err := s.client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s3Key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", contextError(ctx, err))
+	}
+
+	return aws.StringValue(out.UploadId), nil
+}
+
+// GetUploadPartURL generates a pre-signed URL for uploading one part with HTTP PUT
+func (s *S3Service) GetUploadPartURL(ctx context.Context, s3Key, uploadID string, partNumber int64, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return "", err
+	}
+
+	req, _ := s.client.UploadPartRequest(&s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(s3Key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int64(partNumber),
+	})
+	req.SetContext(ctx)
+
+	url, err := req.Presign(expiration)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pre-signed part URL: %w", err)
+	}
+
+	return url, nil
+}
+
+// ListUploadedParts returns the parts stored so far, ordered by part number.
+// Clients use it to resume an interrupted upload.
+func (s *S3Service) ListUploadedParts(ctx context.Context, s3Key, uploadID string) ([]UploadedPart, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return nil, err
+	}
+	var parts []UploadedPart
+	err := s.client.ListPartsPagesWithContext(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(s3Key),
+		UploadId: aws.String(uploadID),
+	}, func(page *s3.ListPartsOutput, lastPage bool) bool {
+		for _, part := range page.Parts {
+			parts = append(parts, UploadedPart{
+				PartNumber: aws.Int64Value(part.PartNumber),
+				ETag:       aws.StringValue(part.ETag),
+				Size:       aws.Int64Value(part.Size),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploaded parts: %w", contextError(ctx, err))
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final object
+func (s *S3Service) CompleteMultipartUpload(ctx context.Context, s3Key, uploadID string, parts []UploadedPart) error {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return err
+	}
+	completed := make([]*s3.CompletedPart, len(parts))
+	for i, part := range parts {
+		completed[i] = &s3.CompletedPart{
+			PartNumber: aws.Int64(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		}
+	}
+
+	_, err := s.client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(s3Key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", contextError(ctx, err))
+	}
+
+	return nil
+}
+
+// AbortMultipartUpload cancels a multipart upload and discards its parts
+func (s *S3Service) AbortMultipartUpload(ctx context.Context, s3Key, uploadID string) error {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return err
+	}
+	_, err := s.client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(s3Key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", contextError(ctx, err))
+	}
+
+	return nil
+}
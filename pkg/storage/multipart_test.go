@@ -0,0 +1,35 @@
+package storage
+
+import "testing"
+
+func TestPartSize(t *testing.T) {
+	const (
+		mb = 1024 * 1024
+		gb = 1024 * mb
+	)
+	tests := []struct {
+		name     string
+		fileSize int64
+		want     int64
+	}{
+		{name: "one byte", fileSize: 1, want: MinPartSize},
+		{name: "fits in minimum parts", fileSize: MinPartSize * MaxParts, want: MinPartSize},
+		{name: "rounded up to megabytes", fileSize: MinPartSize*MaxParts + 1, want: MinPartSize + mb},
+		{name: "largest object", fileSize: MaxObjectSize, want: 525 * mb},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := PartSize(tt.fileSize)
+			if size != tt.want {
+				t.Fatalf("PartSize(%d) = %d, want %d", tt.fileSize, size, tt.want)
+			}
+			if count := PartCount(tt.fileSize, size); count < 1 || count > MaxParts {
+				t.Fatalf("PartCount(%d, %d) = %d, want 1..%d", tt.fileSize, size, count, MaxParts)
+			}
+			if size > 5*gb {
+				t.Fatalf("PartSize(%d) = %d exceeds the 5 GB part limit", tt.fileSize, size)
+			}
+		})
+	}
+}
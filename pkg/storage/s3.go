@@ -1,19 +1,23 @@
 package storage
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"path/filepath"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/google/uuid"
 )
 
-// S3Service handles all S3 operations
+// S3Service handles all S3 operations and implements MultipartStorage
 type S3Service struct {
 	client   *s3.S3
 	uploader *s3manager.Uploader
@@ -23,6 +27,11 @@ type S3Service struct {
 
 // NewS3Service creates a new S3 service
 func NewS3Service(bucket, region string) (*S3Service, error) {
+	// Services without file storage do not set S3_BUCKET, so it is checked here
+	if bucket == "" {
+		return nil, fmt.Errorf("S3_BUCKET environment variable is required")
+	}
+
 	sess, err := session.NewSession(&aws.Config{
 		Region: aws.String(region),
 	})
@@ -46,35 +55,50 @@ type UploadFileData struct {
 }
 
 // UploadFile uploads a file to S3 and returns the S3 key and URL
-func (s *S3Service) UploadFile(data UploadFileData) (string, string, error) {
-	// Generate unique key for the file
-	fileExt := filepath.Ext(data.FileName)
-	uniqueID := uuid.New().String()
-	s3Key := fmt.Sprintf("documents/%s%s", uniqueID, fileExt)
-
-	// Upload to S3
-	_, err := s.uploader.Upload(&s3manager.UploadInput{
+func (s *S3Service) UploadFile(ctx context.Context, data UploadFileData) (string, string, error) {
+	return uploadFile(ctx, s, data)
+}
+
+// UploadStream uploads everything read from body under s3Key.
+// The body is streamed; large bodies are sent as multipart uploads.
+func (s *S3Service) UploadStream(ctx context.Context, s3Key string, body io.Reader, contentType string) error {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return err
+	}
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
 		Bucket:      aws.String(s.bucket),
 		Key:         aws.String(s3Key),
-		Body:        bytes.NewReader(data.FileContent),
-		ContentType: aws.String(data.ContentType),
+		Body:        body,
+		ContentType: aws.String(contentType),
 	})
 	if err != nil {
-		return "", "", fmt.Errorf("failed to upload file to S3: %w", err)
+		return fmt.Errorf("failed to upload file to S3: %w", contextError(ctx, err))
 	}
 
-	// Generate S3 URL
-	s3URL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, s3Key)
+	return nil
+}
+
+// NewFileKey generates a unique S3 key for a file of a tenant, keeping its extension
+func NewFileKey(tenantID, fileName string) string {
+	return fmt.Sprintf("%sdocuments/%s%s", TenantPrefix(tenantID), uuid.New().String(), filepath.Ext(fileName))
+}
 
-	return s3Key, s3URL, nil
+// FileURL returns the (non-signed) S3 URL of a key
+func (s *S3Service) FileURL(s3Key string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, s3Key)
 }
 
 // GetFileURL generates a pre-signed URL for downloading a file
-func (s *S3Service) GetFileURL(s3Key string, expiration time.Duration) (string, error) {
+func (s *S3Service) GetFileURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return "", err
+	}
+
 	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(s3Key),
 	})
+	req.SetContext(ctx)
 
 	url, err := req.Presign(expiration)
 	if err != nil {
@@ -84,31 +108,155 @@ func (s *S3Service) GetFileURL(s3Key string, expiration time.Duration) (string,
 	return url, nil
 }
 
+// GetUploadURL generates a pre-signed URL for uploading a file with HTTP PUT.
+// The client must send the same Content-Type header that was signed.
+func (s *S3Service) GetUploadURL(ctx context.Context, s3Key, contentType string, expiration time.Duration) (string, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return "", err
+	}
+
+	req, _ := s.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s3Key),
+		ContentType: aws.String(contentType),
+	})
+	req.SetContext(ctx)
+
+	url, err := req.Presign(expiration)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pre-signed upload URL: %w", err)
+	}
+
+	return url, nil
+}
+
+// HeadFile returns the size and content type of a stored file
+func (s *S3Service) HeadFile(ctx context.Context, s3Key string) (*FileInfo, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return nil, err
+	}
+	out, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s3Key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("failed to get file info from S3: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get file info from S3: %w", contextError(ctx, err))
+	}
+
+	return &FileInfo{
+		Key:          s3Key,
+		Size:         aws.Int64Value(out.ContentLength),
+		ContentType:  aws.StringValue(out.ContentType),
+		LastModified: aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+// ListFiles returns the files whose key starts with prefix
+func (s *S3Service) ListFiles(ctx context.Context, prefix string) ([]FileInfo, error) {
+	if err := checkTenantKey(ctx, prefix); err != nil {
+		return nil, err
+	}
+	var files []FileInfo
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			files = append(files, FileInfo{
+				Key:          aws.StringValue(object.Key),
+				Size:         aws.Int64Value(object.Size),
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in S3: %w", contextError(ctx, err))
+	}
+
+	return files, nil
+}
+
 // DeleteFile deletes a file from S3
-func (s *S3Service) DeleteFile(s3Key string) error {
-	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+func (s *S3Service) DeleteFile(ctx context.Context, s3Key string) error {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return err
+	}
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(s3Key),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete file from S3: %w", err)
+		return fmt.Errorf("failed to delete file from S3: %w", contextError(ctx, err))
 	}
 
 	return nil
 }
 
 // GetFile downloads a file from S3
-func (s *S3Service) GetFile(s3Key string) ([]byte, error) {
-	buff := &aws.WriteAtBuffer{}
-	downloader := s3manager.NewDownloaderWithClient(s.client)
+func (s *S3Service) GetFile(ctx context.Context, s3Key string) ([]byte, error) {
+	return getFile(ctx, s, s3Key)
+}
 
-	_, err := downloader.Download(buff, &s3.GetObjectInput{
+// Download streams a file from S3 into w and returns the number of bytes written
+func (s *S3Service) Download(ctx context.Context, s3Key string, w io.Writer) (int64, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return 0, err
+	}
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(s3Key),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to download file from S3: %w", err)
+		if isNotFound(err) {
+			return 0, fmt.Errorf("failed to download file from S3: %w", ErrNotFound)
+		}
+		return 0, fmt.Errorf("failed to download file from S3: %w", contextError(ctx, err))
 	}
+	defer out.Body.Close()
 
-	return buff.Bytes(), nil
+	written, err := io.Copy(w, out.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to download file from S3: %w", contextError(ctx, err))
+	}
+
+	return written, nil
+}
+
+// GetRange opens the inclusive byte range [start, end] of a file.
+// The caller must close the returned reader.
+func (s *S3Service) GetRange(ctx context.Context, s3Key string, start, end int64) (io.ReadCloser, error) {
+	if err := checkTenantKey(ctx, s3Key); err != nil {
+		return nil, err
+	}
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid byte range %d-%d", start, end)
+	}
+
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s3Key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("failed to read file range from S3: %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to read file range from S3: %w", contextError(ctx, err))
+	}
+
+	return out.Body, nil
+}
+
+// isNotFound reports whether S3 answered that the object does not exist:
+// NoSuchKey for GET, a bare 404 for HEAD
+func isNotFound(err error) bool {
+	var aerr awserr.RequestFailure
+	if !errors.As(err, &aerr) {
+		return false
+	}
+	return aerr.Code() == s3.ErrCodeNoSuchKey || aerr.StatusCode() == http.StatusNotFound
 }
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no such key", err: awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "missing", nil), http.StatusNotFound, "req"), want: true},
+		{name: "head 404", err: awserr.NewRequestFailure(awserr.New("NotFound", "", nil), http.StatusNotFound, "req"), want: true},
+		{name: "wrapped", err: fmt.Errorf("get: %w", awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchKey, "", nil), http.StatusNotFound, "req")), want: true},
+		{name: "access denied", err: awserr.NewRequestFailure(awserr.New("AccessDenied", "", nil), http.StatusForbidden, "req")},
+		{name: "other error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotFound(tt.err); got != tt.want {
+				t.Fatalf("isNotFound(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
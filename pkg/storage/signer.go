@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"security-questionnaire/pkg/tenant"
+)
+
+var (
+	// ErrInvalidSignature is returned for expired or tampered signed URLs
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+	// ErrSigningKeyRequired is returned when a signer is created without a key
+	ErrSigningKeyRequired = errors.New("a URL signing key is required")
+)
+
+// URLSigner creates and verifies HMAC-signed URLs for backends without native
+// pre-signing, such as the local filesystem and in-memory backends
+type URLSigner struct {
+	baseURL string
+	key     []byte
+}
+
+// NewURLSigner creates a signer for URLs under baseURL. Every process
+// serving the URLs must use the same key, so it cannot be empty.
+func NewURLSigner(baseURL, key string) (*URLSigner, error) {
+	if key == "" {
+		return nil, ErrSigningKeyRequired
+	}
+	return &URLSigner{baseURL: strings.TrimSuffix(baseURL, "/"), key: []byte(key)}, nil
+}
+
+// URL returns the unsigned URL of a key
+func (s *URLSigner) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Sign returns a URL allowing method on key until expiration has passed
+func (s *URLSigner) Sign(method, key string, expiration time.Duration) string {
+	expires := strconv.FormatInt(time.Now().Add(expiration).Unix(), 10)
+	query := url.Values{
+		"method":    {method},
+		"expires":   {expires},
+		"signature": {s.signature(method, key, expires)},
+	}
+	return s.URL(key) + "?" + query.Encode()
+}
+
+// Verify checks the signature of a request for key
+func (s *URLSigner) Verify(method, key string, query url.Values) error {
+	expires := query.Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return ErrInvalidSignature
+	}
+	if query.Get("method") != method {
+		return ErrInvalidSignature
+	}
+
+	expected := s.signature(method, key, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signature computes the HMAC of a method, key and expiry
+func (s *URLSigner) signature(method, key, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSignedURLHandler serves signed GET and PUT URLs issued for a backend.
+// Mount it with http.StripPrefix so the request path is the storage key.
+// A valid signature grants access to the key on behalf of its tenant.
+func NewSignedURLHandler(store Storage, signer *URLSigner) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		if err := signer.Verify(r.Method, key, r.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		ctx := tenant.NewContext(r.Context(), keyTenant(key))
+
+		switch r.Method {
+		case http.MethodGet:
+			info, err := store.HeadFile(ctx, key)
+			if errors.Is(err, ErrNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			if err != nil {
+				log.Printf("Failed to read %s: %v", key, err)
+				http.Error(w, "Failed to read file", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", info.ContentType)
+			w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+			_, _ = store.Download(ctx, key, w)
+
+		case http.MethodPut:
+			if err := store.UploadStream(ctx, key, r.Body, r.Header.Get("Content-Type")); err != nil {
+				log.Printf("Failed to store %s: %v", key, err)
+				http.Error(w, "Failed to store file", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
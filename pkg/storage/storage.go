@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/tenant"
+)
+
+// Storage backends selectable through STORAGE_BACKEND
+const (
+	BackendS3     = "s3"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
+
+var (
+	// ErrNotFound is returned when a file does not exist
+	ErrNotFound = errors.New("file not found")
+	// ErrTimeout is returned when an operation ran past its context's deadline
+	ErrTimeout = errors.New("storage timeout")
+	// ErrOtherTenant is returned for keys outside the tenant of the request
+	ErrOtherTenant = errors.New("key belongs to another tenant")
+)
+
+// Storage is implemented by every file storage backend. Operations stop
+// when their context is done; past its deadline they fail with ErrTimeout.
+// Files of a tenant live under TenantPrefix: UploadFile generates keys for
+// the tenant of its context, and every operation taking a key (or prefix)
+// fails with ErrOtherTenant for keys outside that tenant.
+type Storage interface {
+	// UploadFile stores a file under a newly generated key of the context's
+	// tenant and returns the key and URL
+	UploadFile(ctx context.Context, data UploadFileData) (string, string, error)
+	// UploadStream stores everything read from body under key
+	UploadStream(ctx context.Context, key string, body io.Reader, contentType string) error
+	// GetFile returns the whole content of a file
+	GetFile(ctx context.Context, key string) ([]byte, error)
+	// Download streams a file into w and returns the number of bytes written
+	Download(ctx context.Context, key string, w io.Writer) (int64, error)
+	// GetRange opens the inclusive byte range [start, end] of a file
+	GetRange(ctx context.Context, key string, start, end int64) (io.ReadCloser, error)
+	// GetFileURL returns a signed download URL valid for expiration
+	GetFileURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+	// GetUploadURL returns a signed HTTP PUT URL valid for expiration
+	GetUploadURL(ctx context.Context, key, contentType string, expiration time.Duration) (string, error)
+	// HeadFile returns the size and content type of a file
+	HeadFile(ctx context.Context, key string) (*FileInfo, error)
+	// ListFiles returns the files whose key starts with prefix
+	ListFiles(ctx context.Context, prefix string) ([]FileInfo, error)
+	// DeleteFile removes a file
+	DeleteFile(ctx context.Context, key string) error
+	// FileURL returns the unsigned location of a file
+	FileURL(key string) string
+}
+
+// MultipartStorage is implemented by backends that support client-driven multipart uploads
+type MultipartStorage interface {
+	Storage
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+	GetUploadPartURL(ctx context.Context, key, uploadID string, partNumber int64, expiration time.Duration) (string, error)
+	ListUploadedParts(ctx context.Context, key, uploadID string) ([]UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// FileInfo describes a stored object
+type FileInfo struct {
+	Key          string    `json:"key,omitempty"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"content_type"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// New creates the storage backend selected by the configuration
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageBackend {
+	case BackendS3, "":
+		return NewS3Service(cfg.S3Bucket, cfg.S3Region)
+	case BackendLocal:
+		signer, err := NewURLSigner(cfg.LocalStorageURL, cfg.StorageSigningKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewLocalStorage(cfg.LocalStorageDir, signer)
+	case BackendMemory:
+		return sharedMemoryStorage(cfg.LocalStorageURL, cfg.StorageSigningKey)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.StorageBackend)
+	}
+}
+
+// tenantsPrefix is the common prefix of the keys of all tenants' files
+const tenantsPrefix = "tenants/"
+
+// TenantPrefix returns the prefix of the keys of a tenant's files
+func TenantPrefix(tenantID string) string {
+	return tenantsPrefix + tenantID + "/"
+}
+
+// checkTenantKey makes sure a key or key prefix belongs to the tenant of
+// the request before it is accessed or a URL granting access to it is signed
+func checkTenantKey(ctx context.Context, key string) error {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return fmt.Errorf("%w: cannot access %s", tenant.ErrMissing, key)
+	}
+	rest, ok := strings.CutPrefix(key, TenantPrefix(id))
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrOtherTenant, key)
+	}
+	for _, segment := range strings.Split(rest, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %s", ErrOtherTenant, key)
+		}
+	}
+	return nil
+}
+
+// keyTenant returns the tenant whose prefix key starts with, or ""
+func keyTenant(key string) string {
+	rest, ok := strings.CutPrefix(key, tenantsPrefix)
+	if !ok {
+		return ""
+	}
+	id, _, _ := strings.Cut(rest, "/")
+	return id
+}
+
+// uploadFile implements UploadFile on top of UploadStream for any backend
+func uploadFile(ctx context.Context, s Storage, data UploadFileData) (string, string, error) {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return "", "", fmt.Errorf("failed to upload file: %w", tenant.ErrMissing)
+	}
+
+	key := NewFileKey(id, data.FileName)
+	if err := s.UploadStream(ctx, key, bytes.NewReader(data.FileContent), data.ContentType); err != nil {
+		return "", "", err
+	}
+	return key, s.FileURL(key), nil
+}
+
+// getFile implements GetFile on top of Download for any backend
+func getFile(ctx context.Context, s Storage, key string) ([]byte, error) {
+	var buff bytes.Buffer
+	if _, err := s.Download(ctx, key, &buff); err != nil {
+		return nil, err
+	}
+	return buff.Bytes(), nil
+}
+
+// contextError reports err as ErrTimeout when it happened because ctx ran
+// past its deadline
+func contextError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+	return err
+}
+
+// checkContext returns the context's error, if it is done
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+// contextReader stops reading once its context is done, so copies between
+// readers and writers that know nothing about contexts can be cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read reads from the underlying reader unless the context is done
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, contextError(r.ctx, err)
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"security-questionnaire/pkg/tenant"
+)
+
+func TestCheckTenantKey(t *testing.T) {
+	acme := tenant.NewContext(context.Background(), "acme")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		key     string
+		wantErr error
+	}{
+		{name: "own key", ctx: acme, key: "tenants/acme/documents/a.pdf"},
+		{name: "own prefix", ctx: acme, key: "tenants/acme/"},
+		{name: "other tenant", ctx: acme, key: "tenants/globex/documents/a.pdf", wantErr: ErrOtherTenant},
+		{name: "tenant sharing a prefix", ctx: acme, key: "tenants/acmeco/documents/a.pdf", wantErr: ErrOtherTenant},
+		{name: "unprefixed key", ctx: acme, key: "documents/a.pdf", wantErr: ErrOtherTenant},
+		{name: "parent segment", ctx: acme, key: "tenants/acme/../globex/documents/a.pdf", wantErr: ErrOtherTenant},
+		{name: "current segment", ctx: acme, key: "tenants/acme/./documents/a.pdf", wantErr: ErrOtherTenant},
+		{name: "no tenant", ctx: context.Background(), key: "tenants/acme/documents/a.pdf", wantErr: tenant.ErrMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTenantKey(tt.ctx, tt.key)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("checkTenantKey(%q) = %v, want %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemoryStorageRejectsOtherTenant(t *testing.T) {
+	signer, err := NewURLSigner("http://localhost/files", "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStorage(signer)
+
+	const key = "tenants/globex/documents/a.pdf"
+	globex := tenant.NewContext(context.Background(), "globex")
+	if err := store.UploadStream(globex, key, strings.NewReader("globex"), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+
+	acme := tenant.NewContext(context.Background(), "acme")
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"GetFileURL", func() error { _, err := store.GetFileURL(acme, key, time.Minute); return err }},
+		{"GetUploadURL", func() error { _, err := store.GetUploadURL(acme, key, "application/pdf", time.Minute); return err }},
+		{"GetFile", func() error { _, err := store.GetFile(acme, key); return err }},
+		{"Download", func() error { _, err := store.Download(acme, key, io.Discard); return err }},
+		{"GetRange", func() error { _, err := store.GetRange(acme, key, 0, 1); return err }},
+		{"HeadFile", func() error { _, err := store.HeadFile(acme, key); return err }},
+		{"ListFiles", func() error { _, err := store.ListFiles(acme, TenantPrefix("globex")); return err }},
+		{"UploadStream", func() error { return store.UploadStream(acme, key, strings.NewReader("acme"), "application/pdf") }},
+		{"DeleteFile", func() error { return store.DeleteFile(acme, key) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrOtherTenant) {
+				t.Fatalf("%s = %v, want %v", tt.name, err, ErrOtherTenant)
+			}
+
+			content, err := store.GetFile(globex, key)
+			if err != nil || !bytes.Equal(content, []byte("globex")) {
+				t.Fatalf("file of globex = %q, %v after %s", content, err, tt.name)
+			}
+		})
+	}
+}
+
+func TestSignedURLHandlerServesKeyTenant(t *testing.T) {
+	signer, err := NewURLSigner("http://localhost/files", "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewMemoryStorage(signer)
+
+	const key = "tenants/globex/documents/a.pdf"
+	url, err := store.GetUploadURL(tenant.NewContext(context.Background(), "globex"), key, "application/pdf", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := http.StripPrefix("/files", NewSignedURLHandler(store, signer))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, url, strings.NewReader("globex")))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("PUT %s = %d %s", url, recorder.Code, recorder.Body)
+	}
+
+	if _, err := store.HeadFile(tenant.NewContext(context.Background(), "globex"), key); err != nil {
+		t.Fatalf("uploaded file is missing: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+// Package tenant carries the tenant (organization) of the request being
+// handled in its context. pkg/database scopes every query of tenant-scoped
+// models to it and pkg/storage prefixes file keys with it, so records and
+// files of one tenant are never visible to another.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// Default is the tenant of records created before tenants were introduced
+const Default = "default"
+
+// ErrMissing is returned when a tenant-scoped operation runs without a tenant
+var ErrMissing = errors.New("request has no tenant")
+
+// pattern restricts tenant IDs to characters that are safe in storage keys
+var pattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,62}$`)
+
+// Valid reports whether id is a well-formed tenant ID
+func Valid(id string) bool {
+	return pattern.MatchString(id)
+}
+
+// key is the context key of the tenant
+type key struct{}
+
+// NewContext returns a copy of ctx carrying the tenant id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the tenant of the request being handled, or ""
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
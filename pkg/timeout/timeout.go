@@ -0,0 +1,22 @@
+// Package timeout bounds request handling by the Lambda invocation deadline,
+// so a slow storage call or query fails with a timeout error that can still
+// be reported to the client instead of the invocation being killed.
+package timeout
+
+import (
+	"context"
+	"time"
+)
+
+// WithDeadline returns a copy of ctx that expires margin before ctx's
+// deadline. When ctx has no deadline, as outside Lambda, it expires after
+// fallback instead; a zero fallback leaves it without a deadline.
+func WithDeadline(ctx context.Context, margin, fallback time.Duration) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(ctx, deadline.Add(-margin))
+	}
+	if fallback > 0 {
+		return context.WithTimeout(ctx, fallback)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"context"
+
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/services/document/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// denyDocument audits and refuses access to a document the caller does not own
+func denyDocument(ctx context.Context, doc *models.Document) (events.APIGatewayV2HTTPResponse, error) {
+	return auth.Deny(ctx, auth.Resource{Type: "document", ID: doc.ID}, "document belongs to another owner")
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/router"
+	"security-questionnaire/pkg/storage"
+	"security-questionnaire/services/document/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// maxContentChunk is the largest range served through the API. Bodies are
+// base64 encoded, so this keeps responses under Lambda's 6 MB payload limit.
+const maxContentChunk = 4 * 1024 * 1024
+
+// HandleContent serves the file content of a document, honouring the Range header.
+// Larger files must be fetched in ranges or through the pre-signed download URL.
+func HandleContent(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get document ID from path parameters
+	documentID := request.PathParameters["id"]
+	if documentID == "" {
+		return respond.Error(ctx, 400, "Document ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get document from database
+	documents := database.NewRepository[models.Document](dbService)
+	doc, err := documents.Get(ctx, documentID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to get document")
+	}
+	if !auth.FromContext(ctx).Owns(doc.OwnerID) {
+		return denyDocument(ctx, &doc)
+	}
+	if doc.Status != models.StatusActive {
+		return respond.Error(ctx, 404, "Document not found")
+	}
+
+	// Resolve the requested byte range
+	header := router.Header(request, "Range")
+	if header == "" && doc.FileSize == 0 {
+		// Empty files have no byte range to read
+		return events.APIGatewayV2HTTPResponse{
+			StatusCode: 200,
+			Headers: map[string]string{
+				"Content-Type":  doc.ContentType,
+				"Accept-Ranges": "bytes",
+			},
+		}, nil
+	}
+	start, end := int64(0), doc.FileSize-1
+	partial := false
+	if header != "" {
+		start, end, err = parseRange(header, doc.FileSize)
+		if err != nil {
+			resp, _ := respond.Error(ctx, 416, err.Error())
+			resp.Headers["Content-Range"] = fmt.Sprintf("bytes */%d", doc.FileSize)
+			return resp, nil
+		}
+		partial = true
+	}
+	if end-start+1 > maxContentChunk {
+		if !partial {
+			return respond.Error(ctx, 413, "File is too large to return in one response; request a Range or use the download URL")
+		}
+		end = start + maxContentChunk - 1
+	}
+
+	// Initialize file storage
+	store, err := storage.New(cfg)
+	if err != nil {
+		return respond.Internal(ctx, err, "Failed to initialize storage")
+	}
+
+	body, err := store.GetRange(ctx, doc.S3Key, start, end)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to read file")
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to read file")
+	}
+
+	statusCode := 200
+	headers := map[string]string{
+		"Content-Type":  doc.ContentType,
+		"Accept-Ranges": "bytes",
+	}
+	if partial {
+		statusCode = 206
+		headers["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", start, end, doc.FileSize)
+	}
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      statusCode,
+		Headers:         headers,
+		Body:            base64.StdEncoding.EncodeToString(content),
+		IsBase64Encoded: true,
+	}, nil
+}
+
+// parseRange parses a single "bytes=" range header into inclusive offsets
+func parseRange(header string, size int64) (int64, int64, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, fmt.Errorf("only a single bytes range is supported")
+	}
+
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid range %q", header)
+	}
+
+	var start, end int64
+	var err error
+	switch {
+	case first == "":
+		// Suffix range: the last N bytes
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", header)
+		}
+		if n > size {
+			n = size
+		}
+		start, end = size-n, size-1
+	default:
+		if start, err = strconv.ParseInt(first, 10, 64); err != nil || start < 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", header)
+		}
+		end = size - 1
+		if last != "" {
+			if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+				return 0, 0, fmt.Errorf("invalid range %q", header)
+			}
+		}
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+
+	if start >= size {
+		return 0, 0, fmt.Errorf("range %q is not satisfiable", header)
+	}
+	return start, end, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 
 	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/auth"
 	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
 	"security-questionnaire/pkg/storage"
 	"security-questionnaire/services/document/models"
 
@@ -35,50 +37,60 @@ func HandleCreate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Configuration error: %v", err))
+		return respond.Internal(ctx, err, "Configuration error")
 	}
 
 	// Parse request body
 	var req CreateDocumentRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return ErrorResponse(400, "Invalid request body")
+		return respond.InvalidBody(ctx)
 	}
 
 	// Validate required fields
-	if req.FileName == "" || req.FileContent == "" || req.ContentType == "" {
-		return ErrorResponse(400, "file_name, file_content, and content_type are required")
+	var missing []string
+	if req.FileName == "" {
+		missing = append(missing, "file_name")
+	}
+	if req.FileContent == "" {
+		missing = append(missing, "file_content")
+	}
+	if req.ContentType == "" {
+		missing = append(missing, "content_type")
+	}
+	if len(missing) > 0 {
+		return respond.Required(ctx, "file_name, file_content, and content_type are required", missing...)
 	}
 
 	// Decode base64 file content
 	fileBytes, err := base64.StdEncoding.DecodeString(req.FileContent)
 	if err != nil {
-		return ErrorResponse(400, "Invalid base64 encoded file content")
+		return respond.InvalidFields(ctx, "Invalid base64 encoded file content",
+			respond.FieldError{Field: "file_content", Code: apierror.FieldInvalid, Message: "must be base64 encoded"})
 	}
 
-	// Initialize S3 service
-	s3Service, err := storage.NewS3Service(cfg.S3Bucket, cfg.S3Region)
+	// Initialize file storage
+	store, err := storage.New(cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize S3 service: %v", err))
+		return respond.Internal(ctx, err, "Failed to initialize storage")
 	}
 
 	// Upload file to S3
-	s3Key, s3URL, err := s3Service.UploadFile(storage.UploadFileData{
+	s3Key, s3URL, err := store.UploadFile(ctx, storage.UploadFileData{
 		FileName:    req.FileName,
 		FileContent: fileBytes,
 		ContentType: req.ContentType,
 	})
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to upload file: %v", err))
+		return respond.ErrorFor(ctx, err, "File", "Failed to upload file")
 	}
 
 	// Initialize database service
-	dbService, err := database.NewDatabaseService(cfg.DatabaseURL, &models.Document{})
+	dbService, err := database.GetDatabaseService(ctx, cfg)
 	if err != nil {
 		// Cleanup: delete uploaded file from S3
-		_ = s3Service.DeleteFile(s3Key)
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize database service: %v", err))
+		_ = store.DeleteFile(context.WithoutCancel(ctx), s3Key)
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
 	}
-	defer dbService.Close()
 
 	// Create document record in database
 	doc := &models.Document{
@@ -90,12 +102,14 @@ func HandleCreate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 		S3URL:       s3URL,
 		Description: req.Description,
 		Tags:        req.Tags,
+		Status:      models.StatusActive,
+		OwnerID:     auth.FromContext(ctx).Subject,
 	}
 
-	if err := dbService.Create(doc); err != nil {
+	if err := database.NewRepository[models.Document](dbService).Create(ctx, doc); err != nil {
 		// Cleanup: delete uploaded file from S3
-		_ = s3Service.DeleteFile(s3Key)
-		return ErrorResponse(500, fmt.Sprintf("Failed to create document record: %v", err))
+		_ = store.DeleteFile(context.WithoutCancel(ctx), s3Key)
+		return respond.ErrorFor(ctx, err, "Document", "Failed to create document record")
 	}
 
 	// Return success response
@@ -105,5 +119,5 @@ func HandleCreate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 		Data:    doc,
 	}
 
-	return SuccessResponse(201, response)
+	return respond.Success(201, response)
 }
@@ -2,12 +2,18 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
 	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
 	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/requestid"
+	"security-questionnaire/pkg/respond"
 	"security-questionnaire/pkg/storage"
 	"security-questionnaire/services/document/models"
+	resultmodels "security-questionnaire/services/result/models"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -16,49 +22,89 @@ import (
 type DeleteDocumentResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// Warning is set when evidence links were removed by a forced delete
+	Warning string `json:"warning,omitempty"`
 }
 
-// HandleDelete handles deleting a document by ID
+// errResultLocked is returned when a forced delete would change the evidence
+// of a result whose answers are read-only
+var errResultLocked = errors.New("document is evidence for a result that is no longer editable")
+
+// HandleDelete handles deleting a document by ID.
+// Documents referenced as result evidence are only deleted with ?force=true,
+// which also removes the evidence links, and only while every result using
+// them is editable. The file is removed once the database change is committed.
 func HandleDelete(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Configuration error: %v", err))
+		return respond.Internal(ctx, err, "Configuration error")
 	}
 
 	// Get document ID from path parameters
 	documentID := request.PathParameters["id"]
 	if documentID == "" {
-		return ErrorResponse(400, "Document ID is required")
+		return respond.Error(ctx, 400, "Document ID is required")
 	}
 
 	// Initialize database service
-	dbService, err := database.NewDatabaseService(cfg.DatabaseURL, &models.Document{})
+	dbService, err := database.GetDatabaseService(ctx, cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize database service: %v", err))
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
 	}
-	defer dbService.Close()
 
 	// Get document details before deletion (to get S3 key)
-	var doc models.Document
-	if err := dbService.GetByID(&doc, documentID); err != nil {
-		return ErrorResponse(404, "Document not found")
+	documents := database.NewRepository[models.Document](dbService)
+	doc, err := documents.Get(ctx, documentID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to get document")
+	}
+	if !auth.FromContext(ctx).Owns(doc.OwnerID) {
+		return denyDocument(ctx, &doc)
 	}
 
-	// Initialize S3 service
-	s3Service, err := storage.NewS3Service(cfg.S3Bucket, cfg.S3Region)
+	// Refuse to delete documents that are still used as evidence
+	evidenceCount, err := countEvidence(ctx, dbService, documentID)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize S3 service: %v", err))
+		return respond.ErrorFor(ctx, err, "Evidence", "Failed to check evidence references")
+	}
+	force := request.QueryStringParameters["force"] == "true"
+	if evidenceCount > 0 && !force {
+		return respond.Fail(ctx, 409, CodeDocumentInUse, fmt.Sprintf("Document is referenced as evidence by %d result answer(s); pass force=true to delete it anyway", evidenceCount))
 	}
 
-	// Delete file from S3
-	if err := s3Service.DeleteFile(doc.S3Key); err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to delete file from S3: %v", err))
+	// Initialize file storage
+	store, err := storage.New(cfg)
+	if err != nil {
+		return respond.Internal(ctx, err, "Failed to initialize storage")
 	}
 
-	// Delete document from database
-	if err := dbService.Delete(&models.Document{}, documentID); err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to delete document: %v", err))
+	// Delete the document and its evidence links together
+	err = dbService.WithTransaction(ctx, func(tx *database.DatabaseService) error {
+		if evidenceCount > 0 {
+			if err := checkResultsEditable(ctx, tx, documentID); err != nil {
+				return err
+			}
+		}
+		if err := database.NewRepository[models.Document](tx).Delete(ctx, documentID); err != nil {
+			return err
+		}
+		if evidenceCount == 0 {
+			return nil
+		}
+		_, err := database.NewRepository[resultmodels.Evidence](tx).Purge(ctx, database.Eq("document_id", documentID))
+		return err
+	})
+	if errors.Is(err, errResultLocked) {
+		return respond.Fail(ctx, 409, CodeResultLocked, "Document is evidence for a result that is no longer editable")
+	}
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to delete document")
+	}
+
+	// The document is gone; a file that cannot be removed is only orphaned
+	if err := store.DeleteFile(ctx, doc.S3Key); err != nil {
+		log.Printf("request_id=%s error=failed to delete file %s of document %s: %v", requestid.FromContext(ctx), doc.S3Key, documentID, err)
 	}
 
 	// Return success response
@@ -66,6 +112,43 @@ func HandleDelete(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 		Success: true,
 		Message: "Document deleted successfully",
 	}
+	if evidenceCount > 0 {
+		response.Warning = fmt.Sprintf("Removed %d evidence link(s) that referenced this document", evidenceCount)
+	}
+
+	return respond.Success(200, response)
+}
+
+// checkResultsEditable locks the results using the document as evidence and
+// returns errResultLocked unless all of them are still editable
+func checkResultsEditable(ctx context.Context, tx *database.DatabaseService, documentID string) error {
+	links, err := database.NewRepository[resultmodels.Evidence](tx).List(ctx, database.ListOptions{
+		Filters: []database.Filter{database.Eq("document_id", documentID)},
+	})
+	if err != nil {
+		return err
+	}
+	resultIDs := make([]string, len(links))
+	for i, link := range links {
+		resultIDs[i] = link.ResultID
+	}
+
+	results, err := database.NewRepository[resultmodels.Result](tx).List(ctx, database.ListOptions{
+		Filters:   []database.Filter{database.In("id", resultIDs)},
+		ForUpdate: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if !result.Status.Editable() {
+			return fmt.Errorf("%w: result %s is %s", errResultLocked, result.ID, result.Status)
+		}
+	}
+	return nil
+}
 
-	return SuccessResponse(200, response)
+// countEvidence returns how many result answers use the document as evidence
+func countEvidence(ctx context.Context, dbService *database.DatabaseService, documentID string) (int64, error) {
+	return database.NewRepository[resultmodels.Evidence](dbService).Count(ctx, database.Eq("document_id", documentID))
 }
@@ -0,0 +1,17 @@
+package handlers
+
+// Error codes specific to the document service
+const (
+	// CodeDocumentInUse: the document is evidence for a result and force was not set
+	CodeDocumentInUse = "document_in_use"
+	// CodeResultLocked: a forced delete would change the evidence of a result that is no longer editable
+	CodeResultLocked = "result_locked"
+	// CodeUploadIncomplete: the file or some of its parts have not been uploaded yet
+	CodeUploadIncomplete = "upload_incomplete"
+	// CodeUploadConfirmed: the upload was already confirmed
+	CodeUploadConfirmed = "upload_already_confirmed"
+	// CodeMultipartUpload: the document uses a multipart upload, which must be completed instead
+	CodeMultipartUpload = "multipart_upload"
+	// CodeUploadMismatch: the uploaded file differs from what was declared
+	CodeUploadMismatch = "upload_mismatch"
+)
@@ -2,11 +2,12 @@ package handlers
 
 import (
 	"context"
-	"fmt"
-	"strconv"
 
 	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
 	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/query"
+	"security-questionnaire/pkg/respond"
 	"security-questionnaire/services/document/models"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -14,61 +15,70 @@ import (
 
 // ListDocumentsResponse represents the response for listing documents
 type ListDocumentsResponse struct {
-	Success bool              `json:"success"`
-	Message string            `json:"message"`
-	Data    []models.Document `json:"data"`
-	Total   int64             `json:"total"`
-	Limit   int               `json:"limit"`
-	Offset  int               `json:"offset"`
+	Success    bool              `json:"success"`
+	Message    string            `json:"message"`
+	Data       []models.Document `json:"data"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
+	Total      *int64            `json:"total,omitempty"`
+	Limit      int               `json:"limit"`
 }
 
-// HandleList handles listing all documents with pagination
+// listSpec lists the filter and sort parameters accepted by HandleList
+var listSpec = query.Spec{
+	Params: []query.Param{
+		{Name: "content_type", Column: "content_type", Operator: database.OpEq},
+		{Name: "tag", Column: "tags", Operator: database.OpListContains},
+		{Name: "file_name", Column: "file_name", Operator: database.OpContains},
+		{Name: "created_after", Column: "created_at", Operator: database.OpGte, Type: query.Time},
+		{Name: "created_before", Column: "created_at", Operator: database.OpLt, Type: query.Time},
+		{Name: "min_size", Column: "file_size", Operator: database.OpGte, Type: query.Int},
+		{Name: "max_size", Column: "file_size", Operator: database.OpLte, Type: query.Int},
+	},
+	Sortable: []string{"created_at", "file_name", "file_size", "content_type"},
+}
+
+// HandleList handles listing documents with filters, sorting and cursor pagination
 func HandleList(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Configuration error: %v", err))
+		return respond.Internal(ctx, err, "Configuration error")
 	}
 
-	// Parse pagination parameters
-	limit := 10 // default
-	offset := 0 // default
-
-	if limitStr := request.QueryStringParameters["limit"]; limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-
-	if offsetStr := request.QueryStringParameters["offset"]; offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	// Parse pagination, filter and sort parameters
+	pageOpts, err := query.Parse(request.QueryStringParameters, listSpec)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Invalid query")
 	}
 
 	// Initialize database service
-	dbService, err := database.NewDatabaseService(cfg.DatabaseURL, &models.Document{})
+	dbService, err := database.GetDatabaseService(ctx, cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize database service: %v", err))
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
 	}
-	defer dbService.Close()
 
-	// Get documents from database
-	var documents []models.Document
-	total, err := dbService.List(&models.Document{}, &documents, limit, offset)
+	// Get one page of documents; pending uploads and, for vendors, other
+	// owners' documents are not listed
+	pageOpts.Filters = append(pageOpts.Filters, database.Eq("status", models.StatusActive))
+	if identity := auth.FromContext(ctx); identity.Scoped() {
+		pageOpts.Filters = append(pageOpts.Filters, database.Eq("owner_id", identity.Subject))
+	}
+	page, err := database.NewRepository[models.Document](dbService).Page(ctx, pageOpts)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to list documents: %v", err))
+		return respond.ErrorFor(ctx, err, "Document", "Failed to list documents")
 	}
 
 	// Return success response
 	response := ListDocumentsResponse{
-		Success: true,
-		Message: "Documents retrieved successfully",
-		Data:    documents,
-		Total:   total,
-		Limit:   limit,
-		Offset:  offset,
+		Success:    true,
+		Message:    "Documents retrieved successfully",
+		Data:       page.Items,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Total:      page.Total,
+		Limit:      page.Limit,
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/storage"
+	"security-questionnaire/pkg/tenant"
+	"security-questionnaire/services/document/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// maxPartURLsPerRequest bounds how many part URLs are signed per call
+const maxPartURLsPerRequest = 100
+
+// CreateMultipartUploadResponse represents the response for starting a multipart upload
+type CreateMultipartUploadResponse struct {
+	Success   bool             `json:"success"`
+	Message   string           `json:"message"`
+	Data      *models.Document `json:"data,omitempty"`
+	PartSize  int64            `json:"part_size"`
+	PartCount int64            `json:"part_count"`
+}
+
+// PartURLsRequest represents the request body for signing part upload URLs
+type PartURLsRequest struct {
+	PartNumbers []int64 `json:"part_numbers"`
+}
+
+// PartURLsResponse represents the response for signing part upload URLs
+type PartURLsResponse struct {
+	Success         bool             `json:"success"`
+	Message         string           `json:"message"`
+	URLs            map[int64]string `json:"urls"`
+	UploadExpiresIn string           `json:"upload_expires_in"`
+}
+
+// ListPartsResponse represents the response for listing uploaded parts
+type ListPartsResponse struct {
+	Success   bool                   `json:"success"`
+	Message   string                 `json:"message"`
+	Parts     []storage.UploadedPart `json:"parts"`
+	PartSize  int64                  `json:"part_size"`
+	PartCount int64                  `json:"part_count"`
+}
+
+// HandleCreateMultipartUpload creates a pending document backed by an S3 multipart upload.
+// Clients then request part URLs, PUT each part and call complete.
+func HandleCreateMultipartUpload(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse request body
+	var req RequestUploadRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Validate required fields
+	if errs := req.fieldErrors(); len(errs) > 0 {
+		return respond.InvalidFields(ctx, "file_name, content_type, and a positive file_size are required", errs...)
+	}
+	if req.FileSize > storage.MaxObjectSize {
+		return respond.InvalidFields(ctx, "file_size exceeds the 5 TB upload limit",
+			respond.FieldError{Field: "file_size", Code: apierror.FieldOutOfRange, Message: "file_size exceeds the 5 TB upload limit"})
+	}
+
+	// Initialize file storage
+	store, err := multipartStorage(cfg)
+	if errors.Is(err, errMultipartUnsupported) {
+		return respond.Error(ctx, 501, "Multipart uploads are not supported by the configured storage backend")
+	}
+	if err != nil {
+		return respond.Internal(ctx, err, "Failed to initialize storage")
+	}
+
+	s3Key := storage.NewFileKey(tenant.FromContext(ctx), req.FileName)
+	uploadID, err := store.CreateMultipartUpload(ctx, s3Key, req.ContentType)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to start multipart upload")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		_ = store.AbortMultipartUpload(context.WithoutCancel(ctx), s3Key, uploadID)
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Create pending document record; FileSize holds the declared size until completed
+	doc := &models.Document{
+		FileName:    req.FileName,
+		FileSize:    req.FileSize,
+		ContentType: req.ContentType,
+		S3Bucket:    cfg.S3Bucket,
+		S3Key:       s3Key,
+		S3URL:       store.FileURL(s3Key),
+		Description: req.Description,
+		Tags:        req.Tags,
+		Status:      models.StatusPending,
+		UploadID:    uploadID,
+		OwnerID:     auth.FromContext(ctx).Subject,
+	}
+
+	if err := database.NewRepository[models.Document](dbService).Create(ctx, doc); err != nil {
+		_ = store.AbortMultipartUpload(context.WithoutCancel(ctx), s3Key, uploadID)
+		return respond.ErrorFor(ctx, err, "Document", "Failed to create document record")
+	}
+
+	partSize := storage.PartSize(req.FileSize)
+
+	// Return success response
+	response := CreateMultipartUploadResponse{
+		Success:   true,
+		Message:   "Multipart upload started",
+		Data:      doc,
+		PartSize:  partSize,
+		PartCount: storage.PartCount(req.FileSize, partSize),
+	}
+
+	return respond.Success(201, response)
+}
+
+// HandleGetPartURLs signs upload URLs for the requested part numbers
+func HandleGetPartURLs(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse request body
+	var req PartURLsRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+	if len(req.PartNumbers) == 0 || len(req.PartNumbers) > maxPartURLsPerRequest {
+		return respond.InvalidFields(ctx, fmt.Sprintf("part_numbers must contain between 1 and %d parts", maxPartURLsPerRequest),
+			respond.FieldError{Field: "part_numbers", Code: apierror.FieldOutOfRange, Message: fmt.Sprintf("must contain between 1 and %d parts", maxPartURLsPerRequest)})
+	}
+
+	doc, store, errResp := loadMultipartUpload(ctx, cfg, request)
+	if errResp != nil {
+		return *errResp, nil
+	}
+
+	partCount := storage.PartCount(doc.FileSize, storage.PartSize(doc.FileSize))
+	urls := make(map[int64]string, len(req.PartNumbers))
+	for _, partNumber := range req.PartNumbers {
+		if partNumber < 1 || partNumber > partCount {
+			return respond.InvalidFields(ctx, fmt.Sprintf("part number %d is outside 1-%d", partNumber, partCount),
+				respond.FieldError{Field: "part_numbers", Code: apierror.FieldOutOfRange, Message: fmt.Sprintf("part number %d is outside 1-%d", partNumber, partCount)})
+		}
+		url, err := store.GetUploadPartURL(ctx, doc.S3Key, doc.UploadID, partNumber, uploadURLExpiration)
+		if err != nil {
+			return respond.ErrorFor(ctx, err, "File", "Failed to generate part URL")
+		}
+		urls[partNumber] = url
+	}
+
+	// Return success response
+	response := PartURLsResponse{
+		Success:         true,
+		Message:         "Part upload URLs generated",
+		URLs:            urls,
+		UploadExpiresIn: "15 minutes",
+	}
+
+	return respond.Success(200, response)
+}
+
+// HandleListParts lists the parts uploaded so far so clients can resume
+func HandleListParts(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	doc, store, errResp := loadMultipartUpload(ctx, cfg, request)
+	if errResp != nil {
+		return *errResp, nil
+	}
+
+	parts, err := store.ListUploadedParts(ctx, doc.S3Key, doc.UploadID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to list uploaded parts")
+	}
+
+	partSize := storage.PartSize(doc.FileSize)
+
+	// Return success response
+	response := ListPartsResponse{
+		Success:   true,
+		Message:   "Uploaded parts retrieved successfully",
+		Parts:     parts,
+		PartSize:  partSize,
+		PartCount: storage.PartCount(doc.FileSize, partSize),
+	}
+
+	return respond.Success(200, response)
+}
+
+// HandleCompleteMultipartUpload assembles the uploaded parts and activates the document.
+// Parts are read back from S3, so clients do not need to track ETags.
+func HandleCompleteMultipartUpload(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	doc, store, errResp := loadMultipartUpload(ctx, cfg, request)
+	if errResp != nil {
+		return *errResp, nil
+	}
+
+	parts, err := store.ListUploadedParts(ctx, doc.S3Key, doc.UploadID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to list uploaded parts")
+	}
+
+	// Every part must be present and the sizes must add up to the declared size
+	partCount := storage.PartCount(doc.FileSize, storage.PartSize(doc.FileSize))
+	var total int64
+	for i, part := range parts {
+		if part.PartNumber != int64(i+1) {
+			return respond.Fail(ctx, 409, CodeUploadIncomplete, fmt.Sprintf("Part %d has not been uploaded", i+1))
+		}
+		total += part.Size
+	}
+	if int64(len(parts)) != partCount {
+		return respond.Fail(ctx, 409, CodeUploadIncomplete, fmt.Sprintf("%d of %d parts have been uploaded", len(parts), partCount))
+	}
+	if total != doc.FileSize {
+		return respond.Fail(ctx, 422, CodeUploadMismatch, fmt.Sprintf("Uploaded parts total %d bytes but %d bytes were declared", total, doc.FileSize))
+	}
+
+	if err := store.CompleteMultipartUpload(ctx, doc.S3Key, doc.UploadID, parts); err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to complete multipart upload")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Activate document
+	updates := map[string]interface{}{"status": models.StatusActive, "upload_id": ""}
+	if err := database.NewRepository[models.Document](dbService).Patch(ctx, doc, updates); err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to activate document")
+	}
+
+	// Return success response
+	response := ConfirmUploadResponse{
+		Success: true,
+		Message: "Multipart upload completed",
+		Data:    doc,
+	}
+
+	return respond.Success(200, response)
+}
+
+// HandleAbortMultipartUpload cancels a multipart upload and removes the pending document
+func HandleAbortMultipartUpload(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	doc, store, errResp := loadMultipartUpload(ctx, cfg, request)
+	if errResp != nil {
+		return *errResp, nil
+	}
+
+	if err := store.AbortMultipartUpload(ctx, doc.S3Key, doc.UploadID); err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to abort multipart upload")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	if err := database.NewRepository[models.Document](dbService).Delete(ctx, doc.ID); err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to delete document")
+	}
+
+	// Return success response
+	response := DeleteDocumentResponse{
+		Success: true,
+		Message: "Multipart upload aborted",
+	}
+
+	return respond.Success(200, response)
+}
+
+// loadMultipartUpload loads the pending document of a multipart upload.
+// On failure it returns the error response to send.
+func loadMultipartUpload(ctx context.Context, cfg *config.Config, request events.APIGatewayV2HTTPRequest) (*models.Document, storage.MultipartStorage, *events.APIGatewayV2HTTPResponse) {
+	fail := func(statusCode int, message string) (*models.Document, storage.MultipartStorage, *events.APIGatewayV2HTTPResponse) {
+		resp, _ := respond.Error(ctx, statusCode, message)
+		return nil, nil, &resp
+	}
+	failFor := func(err error, resource, action string) (*models.Document, storage.MultipartStorage, *events.APIGatewayV2HTTPResponse) {
+		resp, _ := respond.ErrorFor(ctx, err, resource, action)
+		return nil, nil, &resp
+	}
+
+	// Get document ID from path parameters
+	documentID := request.PathParameters["id"]
+	if documentID == "" {
+		return fail(400, "Document ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return failFor(err, "Database", "Failed to initialize database service")
+	}
+
+	doc, err := database.NewRepository[models.Document](dbService).Get(ctx, documentID)
+	if err != nil {
+		return failFor(err, "Document", "Failed to get document")
+	}
+	if !auth.FromContext(ctx).Owns(doc.OwnerID) {
+		resp, _ := denyDocument(ctx, &doc)
+		return nil, nil, &resp
+	}
+	if doc.Status != models.StatusPending || doc.UploadID == "" {
+		return fail(409, "Document has no multipart upload in progress")
+	}
+
+	// Initialize file storage
+	store, err := multipartStorage(cfg)
+	if errors.Is(err, errMultipartUnsupported) {
+		return fail(501, "Multipart uploads are not supported by the configured storage backend")
+	}
+	if err != nil {
+		resp, _ := respond.Internal(ctx, err, "Failed to initialize storage")
+		return nil, nil, &resp
+	}
+
+	return &doc, store, nil
+}
+
+// errMultipartUnsupported is returned when the storage backend cannot do multipart uploads
+var errMultipartUnsupported = errors.New("storage backend does not support multipart uploads")
+
+// multipartStorage returns the configured storage backend if it supports multipart uploads
+func multipartStorage(cfg *config.Config) (storage.MultipartStorage, error) {
+	store, err := storage.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	multipart, ok := store.(storage.MultipartStorage)
+	if !ok {
+		return nil, errMultipartUnsupported
+	}
+	return multipart, nil
+}
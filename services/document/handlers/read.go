@@ -2,11 +2,12 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
 	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
 	"security-questionnaire/pkg/storage"
 	"security-questionnaire/services/document/models"
 
@@ -27,38 +28,50 @@ func HandleRead(ctx context.Context, request events.APIGatewayV2HTTPRequest) (ev
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Configuration error: %v", err))
+		return respond.Internal(ctx, err, "Configuration error")
 	}
 
 	// Get document ID from path parameters
 	documentID := request.PathParameters["id"]
 	if documentID == "" {
-		return ErrorResponse(400, "Document ID is required")
+		return respond.Error(ctx, 400, "Document ID is required")
 	}
 
 	// Initialize database service
-	dbService, err := database.NewDatabaseService(cfg.DatabaseURL, &models.Document{})
+	dbService, err := database.GetDatabaseService(ctx, cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize database service: %v", err))
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
 	}
-	defer dbService.Close()
 
 	// Get document from database
-	var doc models.Document
-	if err := dbService.GetByID(&doc, documentID); err != nil {
-		return ErrorResponse(404, "Document not found")
+	documents := database.NewRepository[models.Document](dbService)
+	doc, err := documents.Get(ctx, documentID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to get document")
+	}
+	if !auth.FromContext(ctx).Owns(doc.OwnerID) {
+		return denyDocument(ctx, &doc)
+	}
+
+	// Pending uploads have no file to download yet
+	if doc.Status == models.StatusPending {
+		return respond.Success(200, ReadDocumentResponse{
+			Success: true,
+			Message: "Document upload has not been confirmed yet",
+			Data:    &doc,
+		})
 	}
 
-	// Initialize S3 service to generate pre-signed URL
-	s3Service, err := storage.NewS3Service(cfg.S3Bucket, cfg.S3Region)
+	// Initialize file storage to generate pre-signed URL
+	store, err := storage.New(cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize S3 service: %v", err))
+		return respond.Internal(ctx, err, "Failed to initialize storage")
 	}
 
 	// Generate pre-signed URL (valid for 1 hour)
-	downloadURL, err := s3Service.GetFileURL(doc.S3Key, 1*time.Hour)
+	downloadURL, err := store.GetFileURL(ctx, doc.S3Key, 1*time.Hour)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to generate download URL: %v", err))
+		return respond.ErrorFor(ctx, err, "File", "Failed to generate download URL")
 	}
 
 	// Return success response
@@ -70,5 +83,5 @@ func HandleRead(ctx context.Context, request events.APIGatewayV2HTTPRequest) (ev
 		URLExpiresIn: "1 hour",
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/router"
+)
+
+// Register adds the document service's endpoints to r with the permission each requires
+func Register(r *router.Router) {
+	r.POST("/documents", HandleCreate).Named("documents.create").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.GET("/documents", HandleList).Named("documents.list").With(auth.RequiredPermission, auth.DocumentsRead)
+	r.GET("/documents/{id}", HandleRead).Named("documents.read").With(auth.RequiredPermission, auth.DocumentsRead)
+	r.PUT("/documents/{id}", HandleUpdate).Named("documents.update").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.DELETE("/documents/{id}", HandleDelete).Named("documents.delete").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.GET("/documents/{id}/content", HandleContent).Named("documents.content").With(auth.RequiredPermission, auth.DocumentsRead)
+
+	// Direct and multipart uploads
+	r.POST("/documents/uploads", HandleRequestUpload).Named("documents.uploads.request").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.POST("/documents/{id}/confirm", HandleConfirmUpload).Named("documents.uploads.confirm").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.POST("/documents/multipart-uploads", HandleCreateMultipartUpload).Named("documents.multipart.create").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.POST("/documents/{id}/parts", HandleGetPartURLs).Named("documents.multipart.part_urls").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.GET("/documents/{id}/parts", HandleListParts).Named("documents.multipart.list_parts").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.POST("/documents/{id}/complete", HandleCompleteMultipartUpload).Named("documents.multipart.complete").With(auth.RequiredPermission, auth.DocumentsWrite)
+	r.DELETE("/documents/{id}/upload", HandleAbortMultipartUpload).Named("documents.multipart.abort").With(auth.RequiredPermission, auth.DocumentsWrite)
+}
@@ -3,10 +3,11 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
 	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
 	"security-questionnaire/services/document/models"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -30,19 +31,19 @@ func HandleUpdate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Configuration error: %v", err))
+		return respond.Internal(ctx, err, "Configuration error")
 	}
 
 	// Get document ID from path parameters
 	documentID := request.PathParameters["id"]
 	if documentID == "" {
-		return ErrorResponse(400, "Document ID is required")
+		return respond.Error(ctx, 400, "Document ID is required")
 	}
 
 	// Parse request body
 	var req UpdateDocumentRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return ErrorResponse(400, "Invalid request body")
+		return respond.InvalidBody(ctx)
 	}
 
 	// Build updates map (only include fields that are provided)
@@ -55,20 +56,30 @@ func HandleUpdate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 	}
 
 	if len(updates) == 0 {
-		return ErrorResponse(400, "No fields to update")
+		return respond.Error(ctx, 400, "No fields to update")
 	}
 
 	// Initialize database service
-	dbService, err := database.NewDatabaseService(cfg.DatabaseURL, &models.Document{})
+	dbService, err := database.GetDatabaseService(ctx, cfg)
 	if err != nil {
-		return ErrorResponse(500, fmt.Sprintf("Failed to initialize database service: %v", err))
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	documents := database.NewRepository[models.Document](dbService)
+	if identity := auth.FromContext(ctx); identity.Scoped() {
+		existing, err := documents.Get(ctx, documentID)
+		if err != nil {
+			return respond.ErrorFor(ctx, err, "Document", "Failed to get document")
+		}
+		if !identity.Owns(existing.OwnerID) {
+			return denyDocument(ctx, &existing)
+		}
 	}
-	defer dbService.Close()
 
 	// Update document in database
-	var doc models.Document
-	if err := dbService.Update(&doc, documentID, updates); err != nil {
-		return ErrorResponse(404, "Document not found or failed to update")
+	doc, err := documents.Update(ctx, documentID, updates)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to update document")
 	}
 
 	// Return success response
@@ -78,5 +89,5 @@ func HandleUpdate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (
 		Data:    &doc,
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/storage"
+	"security-questionnaire/pkg/tenant"
+	"security-questionnaire/services/document/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const (
+	// uploadURLExpiration is how long a pre-signed upload URL stays valid
+	uploadURLExpiration = 15 * time.Minute
+	// maxUploadSize is the largest object S3 accepts in a single PUT (5 GB)
+	maxUploadSize = 5 * 1024 * 1024 * 1024
+)
+
+// RequestUploadRequest represents the request body for requesting an upload slot
+type RequestUploadRequest struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+	Description string `json:"description,omitempty"`
+	Tags        string `json:"tags,omitempty"`
+}
+
+// fieldErrors returns the fields of the request that are missing or invalid
+func (r RequestUploadRequest) fieldErrors() []respond.FieldError {
+	var errs []respond.FieldError
+	if r.FileName == "" {
+		errs = append(errs, respond.FieldError{Field: "file_name", Code: apierror.FieldRequired, Message: "file_name is required"})
+	}
+	if r.ContentType == "" {
+		errs = append(errs, respond.FieldError{Field: "content_type", Code: apierror.FieldRequired, Message: "content_type is required"})
+	}
+	if r.FileSize <= 0 {
+		errs = append(errs, respond.FieldError{Field: "file_size", Code: apierror.FieldOutOfRange, Message: "file_size must be positive"})
+	}
+	return errs
+}
+
+// RequestUploadResponse represents the response for requesting an upload slot
+type RequestUploadResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    *models.Document `json:"data,omitempty"`
+	// UploadURL accepts a single HTTP PUT of the file with the signed Content-Type header
+	UploadURL       string            `json:"upload_url"`
+	UploadHeaders   map[string]string `json:"upload_headers"`
+	UploadExpiresIn string            `json:"upload_expires_in"`
+}
+
+// ConfirmUploadResponse represents the response for confirming an upload
+type ConfirmUploadResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    *models.Document `json:"data,omitempty"`
+}
+
+// HandleRequestUpload creates a pending document and returns a pre-signed PUT URL
+// so the client can upload the file directly to S3
+func HandleRequestUpload(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse request body
+	var req RequestUploadRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Validate required fields
+	if errs := req.fieldErrors(); len(errs) > 0 {
+		return respond.InvalidFields(ctx, "file_name, content_type, and a positive file_size are required", errs...)
+	}
+	if req.FileSize > maxUploadSize {
+		return respond.InvalidFields(ctx, "file_size exceeds the 5 GB upload limit",
+			respond.FieldError{Field: "file_size", Code: apierror.FieldOutOfRange, Message: "file_size exceeds the 5 GB upload limit"})
+	}
+
+	// Initialize file storage
+	store, err := storage.New(cfg)
+	if err != nil {
+		return respond.Internal(ctx, err, "Failed to initialize storage")
+	}
+
+	s3Key := storage.NewFileKey(tenant.FromContext(ctx), req.FileName)
+	uploadURL, err := store.GetUploadURL(ctx, s3Key, req.ContentType, uploadURLExpiration)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to generate upload URL")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Create pending document record; FileSize holds the declared size until confirmed
+	doc := &models.Document{
+		FileName:    req.FileName,
+		FileSize:    req.FileSize,
+		ContentType: req.ContentType,
+		S3Bucket:    cfg.S3Bucket,
+		S3Key:       s3Key,
+		S3URL:       store.FileURL(s3Key),
+		Description: req.Description,
+		Tags:        req.Tags,
+		Status:      models.StatusPending,
+		OwnerID:     auth.FromContext(ctx).Subject,
+	}
+
+	if err := database.NewRepository[models.Document](dbService).Create(ctx, doc); err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to create document record")
+	}
+
+	// Return success response
+	response := RequestUploadResponse{
+		Success:         true,
+		Message:         "Upload slot created; PUT the file to upload_url, then confirm the upload",
+		Data:            doc,
+		UploadURL:       uploadURL,
+		UploadHeaders:   map[string]string{"Content-Type": req.ContentType},
+		UploadExpiresIn: "15 minutes",
+	}
+
+	return respond.Success(201, response)
+}
+
+// HandleConfirmUpload verifies that a pending document's file was uploaded
+// with the declared size and content type, then activates the document
+func HandleConfirmUpload(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get document ID from path parameters
+	documentID := request.PathParameters["id"]
+	if documentID == "" {
+		return respond.Error(ctx, 400, "Document ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	documents := database.NewRepository[models.Document](dbService)
+	doc, err := documents.Get(ctx, documentID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to get document")
+	}
+	if !auth.FromContext(ctx).Owns(doc.OwnerID) {
+		return denyDocument(ctx, &doc)
+	}
+	if doc.Status != models.StatusPending {
+		return respond.Fail(ctx, 409, CodeUploadConfirmed, "Document upload is already confirmed")
+	}
+	if doc.UploadID != "" {
+		return respond.Fail(ctx, 409, CodeMultipartUpload, "Document uses a multipart upload; complete it instead")
+	}
+
+	// Initialize file storage
+	store, err := storage.New(cfg)
+	if err != nil {
+		return respond.Internal(ctx, err, "Failed to initialize storage")
+	}
+
+	info, err := store.HeadFile(ctx, doc.S3Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return respond.Fail(ctx, 409, CodeUploadIncomplete, "File has not been uploaded yet")
+	}
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "File", "Failed to check uploaded file")
+	}
+	if info.Size != doc.FileSize {
+		return respond.Fail(ctx, 422, CodeUploadMismatch, fmt.Sprintf("Uploaded file is %d bytes but %d bytes were declared", info.Size, doc.FileSize))
+	}
+	if !strings.EqualFold(info.ContentType, doc.ContentType) {
+		return respond.Fail(ctx, 422, CodeUploadMismatch, fmt.Sprintf("Uploaded file has content type %q but %q was declared", info.ContentType, doc.ContentType))
+	}
+
+	// Activate document
+	if err := documents.Patch(ctx, &doc, map[string]interface{}{"status": models.StatusActive}); err != nil {
+		return respond.ErrorFor(ctx, err, "Document", "Failed to activate document")
+	}
+
+	// Return success response
+	response := ConfirmUploadResponse{
+		Success: true,
+		Message: "Document upload confirmed",
+		Data:    &doc,
+	}
+
+	return respond.Success(200, response)
+}
@@ -15,8 +15,21 @@ type Document struct {
 	S3URL       string `gorm:"column:s3_url;not null" json:"s3_url"`
 	Description string `gorm:"column:description;type:text" json:"description,omitempty"`
 	Tags        string `gorm:"column:tags;type:text" json:"tags,omitempty"`
+	Status      string `gorm:"column:status;not null;default:'active';index" json:"status"`
+	// UploadID is the S3 multipart upload ID while a multipart upload is in progress
+	UploadID string `gorm:"column:upload_id" json:"upload_id,omitempty"`
+	// OwnerID is the subject of the caller who created the document
+	OwnerID string `gorm:"column:owner_id;index" json:"owner_id,omitempty"`
 }
 
+// Document statuses
+const (
+	// StatusPending marks a document whose upload slot was issued but not yet confirmed
+	StatusPending = "pending"
+	// StatusActive marks a document whose file is stored in S3
+	StatusActive = "active"
+)
+
 // TableName specifies the table name for the Document model
 func (Document) TableName() string {
 	return "documents"
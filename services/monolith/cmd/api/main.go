@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/middleware"
+	"security-questionnaire/pkg/router"
+	"security-questionnaire/services/monolith"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// newRouter serves the endpoints of every service behind the standard middleware
+func newRouter(cfg *config.Config) *router.Router {
+	r := router.New()
+	r.Use(middleware.Standard(cfg)...)
+	monolith.Register(r)
+	return r
+}
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
+
+	lambda.Start(newRouter(cfg).Serve)
+}
@@ -0,0 +1,19 @@
+// Package monolith serves the document, questionnaire and result APIs from a
+// single binary. The services keep their own packages and can still be
+// deployed one function each; the monolith only mounts their routes together,
+// so all of them share one configuration and one database pool per process.
+package monolith
+
+import (
+	"security-questionnaire/pkg/router"
+	documenthandlers "security-questionnaire/services/document/handlers"
+	questionnairehandlers "security-questionnaire/services/questionnaire/handlers"
+	resulthandlers "security-questionnaire/services/result/handlers"
+)
+
+// Register registers the endpoints of every service
+func Register(r *router.Router) {
+	documenthandlers.Register(r)
+	questionnairehandlers.Register(r)
+	resulthandlers.Register(r)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// CreateQuestionnaireRequest represents the request body for creating a questionnaire
+type CreateQuestionnaireRequest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Sections    models.Sections `json:"sections,omitempty"`
+}
+
+// CreateQuestionnaireResponse represents the response for creating a questionnaire
+type CreateQuestionnaireResponse struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Data    *models.Questionnaire `json:"data,omitempty"`
+}
+
+// HandleCreate handles the creation of a new questionnaire draft
+func HandleCreate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse request body
+	var req CreateQuestionnaireRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Validate required fields
+	if req.Name == "" {
+		return respond.Required(ctx, "name is required", "name")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Create questionnaire draft in database
+	questionnaire := &models.Questionnaire{
+		Name:        req.Name,
+		Description: req.Description,
+		Sections:    req.Sections,
+	}
+
+	if err := database.NewRepository[models.Questionnaire](dbService).Create(ctx, questionnaire); err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to create questionnaire")
+	}
+
+	// Return success response
+	response := CreateQuestionnaireResponse{
+		Success: true,
+		Message: "Questionnaire created successfully",
+		Data:    questionnaire,
+	}
+
+	return respond.Success(201, response)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// DeleteQuestionnaireResponse represents the response for deleting a questionnaire
+type DeleteQuestionnaireResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// HandleDelete handles deleting a questionnaire by ID.
+// Published versions are kept so existing results can still be interpreted.
+func HandleDelete(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Soft-delete questionnaire draft
+	if err := database.NewRepository[models.Questionnaire](dbService).Delete(ctx, questionnaireID); err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to delete questionnaire")
+	}
+
+	// Return success response
+	response := DeleteQuestionnaireResponse{
+		Success: true,
+		Message: "Questionnaire deleted successfully",
+	}
+
+	return respond.Success(200, response)
+}
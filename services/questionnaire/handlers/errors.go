@@ -0,0 +1,9 @@
+package handlers
+
+// Error codes specific to the questionnaire service
+const (
+	// CodeInvalidDefinition: the draft's sections and questions cannot be published
+	CodeInvalidDefinition = "invalid_definition"
+	// CodeInvalidScoringRules: the scoring rules do not fit the questionnaire version
+	CodeInvalidScoringRules = "invalid_scoring_rules"
+)
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ListQuestionnairesResponse represents the response for listing questionnaires
+type ListQuestionnairesResponse struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Data    []models.Questionnaire `json:"data"`
+	Total   int64                  `json:"total"`
+	Limit   int                    `json:"limit"`
+	Offset  int                    `json:"offset"`
+}
+
+// HandleList handles listing all questionnaires with pagination
+func HandleList(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse pagination parameters
+	limit := database.DefaultPageSize
+	offset := 0 // default
+
+	if limitStr := request.QueryStringParameters["limit"]; limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+	if limit > database.MaxPageSize {
+		limit = database.MaxPageSize
+	}
+
+	if offsetStr := request.QueryStringParameters["offset"]; offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get questionnaires from database
+	repo := database.NewRepository[models.Questionnaire](dbService)
+	total, err := repo.Count(ctx)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to list questionnaires")
+	}
+	questionnaires, err := repo.List(ctx, database.ListOptions{Limit: limit, Offset: offset})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to list questionnaires")
+	}
+
+	// Return success response
+	response := ListQuestionnairesResponse{
+		Success: true,
+		Message: "Questionnaires retrieved successfully",
+		Data:    questionnaires,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+
+	return respond.Success(200, response)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// PublishQuestionnaireResponse represents the response for publishing a questionnaire
+type PublishQuestionnaireResponse struct {
+	Success bool                         `json:"success"`
+	Message string                       `json:"message"`
+	Data    *models.QuestionnaireVersion `json:"data,omitempty"`
+}
+
+// errInvalidDefinition marks a draft that failed validation during publish
+var errInvalidDefinition = errors.New("invalid questionnaire definition")
+
+// HandlePublish snapshots the current draft into a new immutable version
+func HandlePublish(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Lock the draft row so concurrent publishes get sequential version numbers
+	var version models.QuestionnaireVersion
+	err = dbService.WithTransaction(ctx, func(tx *database.DatabaseService) error {
+		questionnaire, err := database.NewRepository[models.Questionnaire](tx).First(ctx, database.ListOptions{
+			Filters:   []database.Filter{database.Eq("id", questionnaireID)},
+			ForUpdate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := questionnaire.Sections.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidDefinition, err)
+		}
+
+		version = models.QuestionnaireVersion{
+			QuestionnaireID: questionnaire.ID,
+			Version:         questionnaire.LatestVersion + 1,
+			Name:            questionnaire.Name,
+			Description:     questionnaire.Description,
+			Sections:        questionnaire.Sections,
+			PublishedAt:     time.Now().UTC(),
+		}
+		if err := database.NewRepository[models.QuestionnaireVersion](tx).Create(ctx, &version); err != nil {
+			return err
+		}
+
+		return database.NewRepository[models.Questionnaire](tx).Patch(ctx, &questionnaire, map[string]interface{}{"latest_version": version.Version})
+	})
+	if errors.Is(err, errInvalidDefinition) {
+		return respond.Fail(ctx, 422, CodeInvalidDefinition, err.Error())
+	}
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to publish questionnaire")
+	}
+
+	// Return success response
+	response := PublishQuestionnaireResponse{
+		Success: true,
+		Message: fmt.Sprintf("Questionnaire published as version %d", version.Version),
+		Data:    &version,
+	}
+
+	return respond.Success(201, response)
+}
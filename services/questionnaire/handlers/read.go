@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ReadQuestionnaireResponse represents the response for reading a questionnaire
+type ReadQuestionnaireResponse struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Data    *models.Questionnaire `json:"data,omitempty"`
+}
+
+// HandleRead handles reading a questionnaire draft by ID
+func HandleRead(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get questionnaire from database
+	questionnaire, err := database.NewRepository[models.Questionnaire](dbService).Get(ctx, questionnaireID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to get questionnaire")
+	}
+
+	// Return success response
+	response := ReadQuestionnaireResponse{
+		Success: true,
+		Message: "Questionnaire retrieved successfully",
+		Data:    &questionnaire,
+	}
+
+	return respond.Success(200, response)
+}
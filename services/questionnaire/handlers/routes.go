@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/router"
+)
+
+// Register adds the questionnaire service's endpoints to r with the permission each requires
+func Register(r *router.Router) {
+	r.POST("/questionnaires", HandleCreate).Named("questionnaires.create").With(auth.RequiredPermission, auth.QuestionnairesWrite)
+	r.GET("/questionnaires", HandleList).Named("questionnaires.list").With(auth.RequiredPermission, auth.QuestionnairesRead)
+	r.GET("/questionnaires/{id}", HandleRead).Named("questionnaires.read").With(auth.RequiredPermission, auth.QuestionnairesRead)
+	r.PUT("/questionnaires/{id}", HandleUpdate).Named("questionnaires.update").With(auth.RequiredPermission, auth.QuestionnairesWrite)
+	r.DELETE("/questionnaires/{id}", HandleDelete).Named("questionnaires.delete").With(auth.RequiredPermission, auth.QuestionnairesWrite)
+
+	// Published versions
+	r.POST("/questionnaires/{id}/publish", HandlePublish).Named("questionnaires.publish").With(auth.RequiredPermission, auth.QuestionnairesWrite)
+	r.GET("/questionnaires/{id}/versions", HandleListVersions).Named("questionnaires.versions.list").With(auth.RequiredPermission, auth.QuestionnairesRead)
+	r.GET("/questionnaires/{id}/versions/{version}", HandleReadVersion).Named("questionnaires.versions.read").With(auth.RequiredPermission, auth.QuestionnairesRead)
+	r.PUT("/questionnaires/{id}/versions/{version}/scoring", HandleUpdateScoring).Named("questionnaires.versions.scoring").With(auth.RequiredPermission, auth.QuestionnairesWrite)
+}
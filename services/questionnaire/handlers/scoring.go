@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// UpdateScoringRequest represents the request body for editing a version's scoring rules
+type UpdateScoringRequest struct {
+	Rules map[string]models.ScoringRule `json:"rules"`
+}
+
+// UpdateScoringResponse represents the response for editing a version's scoring rules
+type UpdateScoringResponse struct {
+	Success bool                         `json:"success"`
+	Message string                       `json:"message"`
+	Data    *models.QuestionnaireVersion `json:"data,omitempty"`
+}
+
+// HandleUpdateScoring replaces weights and expected answers on a published version.
+// Existing results keep their scores until POST /results/recompute is called.
+func HandleUpdateScoring(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID and version from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	versionNumber, err := strconv.Atoi(request.PathParameters["version"])
+	if err != nil || versionNumber <= 0 {
+		return respond.Error(ctx, 400, "Version must be a positive integer")
+	}
+
+	// Parse request body
+	var req UpdateScoringRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	if len(req.Rules) == 0 {
+		return respond.Required(ctx, "rules are required", "rules")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get version from database
+	versions := database.NewRepository[models.QuestionnaireVersion](dbService)
+	version, err := versions.First(ctx, database.ListOptions{
+		Filters: []database.Filter{
+			database.Eq("questionnaire_id", questionnaireID),
+			database.Eq("version", versionNumber),
+		},
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to get questionnaire version")
+	}
+
+	// Apply scoring rules to a copy of the published sections
+	sections, err := version.Sections.ApplyScoringRules(req.Rules)
+	if err != nil {
+		return respond.Fail(ctx, 422, CodeInvalidScoringRules, err.Error())
+	}
+
+	if err := versions.Patch(ctx, &version, map[string]interface{}{"sections": sections}); err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to update scoring rules")
+	}
+	version.Sections = sections
+
+	// Return success response
+	response := UpdateScoringResponse{
+		Success: true,
+		Message: "Scoring rules updated successfully",
+		Data:    &version,
+	}
+
+	return respond.Success(200, response)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// UpdateQuestionnaireRequest represents the request body for updating a questionnaire draft.
+// Published versions are immutable; changes only take effect for results after the next publish.
+type UpdateQuestionnaireRequest struct {
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Sections    *models.Sections `json:"sections,omitempty"`
+}
+
+// UpdateQuestionnaireResponse represents the response for updating a questionnaire
+type UpdateQuestionnaireResponse struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Data    *models.Questionnaire `json:"data,omitempty"`
+}
+
+// HandleUpdate handles updating a questionnaire draft
+func HandleUpdate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	// Parse request body
+	var req UpdateQuestionnaireRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Build updates map (only include fields that are provided)
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		if *req.Name == "" {
+			return respond.InvalidFields(ctx, "name cannot be empty",
+				respond.FieldError{Field: "name", Code: apierror.FieldRequired, Message: "name cannot be empty"})
+		}
+		updates["name"] = *req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Sections != nil {
+		updates["sections"] = *req.Sections
+	}
+
+	if len(updates) == 0 {
+		return respond.Error(ctx, 400, "No fields to update")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Update questionnaire in database
+	questionnaire, err := database.NewRepository[models.Questionnaire](dbService).Update(ctx, questionnaireID, updates)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire", "Failed to update questionnaire")
+	}
+
+	// Return success response
+	response := UpdateQuestionnaireResponse{
+		Success: true,
+		Message: "Questionnaire updated successfully",
+		Data:    &questionnaire,
+	}
+
+	return respond.Success(200, response)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ListVersionsResponse represents the response for listing published versions
+type ListVersionsResponse struct {
+	Success bool                          `json:"success"`
+	Message string                        `json:"message"`
+	Data    []models.QuestionnaireVersion `json:"data"`
+}
+
+// ReadVersionResponse represents the response for reading a published version
+type ReadVersionResponse struct {
+	Success bool                         `json:"success"`
+	Message string                       `json:"message"`
+	Data    *models.QuestionnaireVersion `json:"data,omitempty"`
+}
+
+// HandleListVersions handles listing all published versions of a questionnaire
+func HandleListVersions(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get versions from database, newest first
+	versions, err := database.NewRepository[models.QuestionnaireVersion](dbService).List(ctx, database.ListOptions{
+		Filters: []database.Filter{database.Eq("questionnaire_id", questionnaireID)},
+		Sort:    []database.Sort{database.Desc("version")},
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to list versions")
+	}
+
+	// Return success response
+	response := ListVersionsResponse{
+		Success: true,
+		Message: "Versions retrieved successfully",
+		Data:    versions,
+	}
+
+	return respond.Success(200, response)
+}
+
+// HandleReadVersion handles reading a single published version of a questionnaire
+func HandleReadVersion(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get questionnaire ID and version from path parameters
+	questionnaireID := request.PathParameters["id"]
+	if questionnaireID == "" {
+		return respond.Error(ctx, 400, "Questionnaire ID is required")
+	}
+
+	versionNumber, err := strconv.Atoi(request.PathParameters["version"])
+	if err != nil || versionNumber <= 0 {
+		return respond.Error(ctx, 400, "Version must be a positive integer")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get version from database
+	version, err := database.NewRepository[models.QuestionnaireVersion](dbService).First(ctx, database.ListOptions{
+		Filters: []database.Filter{
+			database.Eq("questionnaire_id", questionnaireID),
+			database.Eq("version", versionNumber),
+		},
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to get questionnaire version")
+	}
+
+	// Return success response
+	response := ReadVersionResponse{
+		Success: true,
+		Message: "Version retrieved successfully",
+		Data:    &version,
+	}
+
+	return respond.Success(200, response)
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NotApplicableAnswer is the answer shape for a question marked not applicable,
+// e.g. {"na": true, "justification": "We do not process cardholder data"}
+type NotApplicableAnswer struct {
+	NA            bool   `json:"na"`
+	Justification string `json:"justification"`
+}
+
+// ParseNotApplicable reports whether an answer is a not applicable answer
+func ParseNotApplicable(answer interface{}) (NotApplicableAnswer, bool) {
+	object, ok := answer.(map[string]interface{})
+	if !ok {
+		return NotApplicableAnswer{}, false
+	}
+	na, _ := object["na"].(bool)
+	if !na {
+		return NotApplicableAnswer{}, false
+	}
+	justification, _ := object["justification"].(string)
+	return NotApplicableAnswer{NA: true, Justification: justification}, true
+}
+
+// NormalizeAnswer converts an answer value into a comparable string.
+// Text is trimmed and lower-cased and booleans become "yes"/"no".
+func NormalizeAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.ToLower(strings.TrimSpace(v))
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return strings.ToLower(strings.TrimSpace(fmt.Sprint(v)))
+	}
+}
+
+// NormalizeAnswers converts a list answer into comparable strings.
+// A single value is treated as a list of one.
+func NormalizeAnswers(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, NormalizeAnswer(item))
+		}
+		return values
+	case []string:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, NormalizeAnswer(item))
+		}
+		return values
+	default:
+		return []string{NormalizeAnswer(v)}
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+)
+
+// ConditionOperator compares an earlier answer with a condition value
+type ConditionOperator string
+
+// Supported condition operators
+const (
+	// OperatorEquals matches when the answer equals Value
+	OperatorEquals ConditionOperator = "equals"
+	// OperatorNotEquals matches when the question is answered with anything but Value
+	OperatorNotEquals ConditionOperator = "not_equals"
+	// OperatorIn matches when the answer is one of the values in Value
+	OperatorIn ConditionOperator = "in"
+	// OperatorContains matches when a multi choice answer includes Value
+	OperatorContains ConditionOperator = "contains"
+	// OperatorAnswered matches when the question has any answer
+	OperatorAnswered ConditionOperator = "answered"
+)
+
+// Condition decides whether a section or question is shown.
+// A leaf condition compares the answer to QuestionKey; All and Any combine nested conditions.
+type Condition struct {
+	QuestionKey string            `json:"question_key,omitempty"`
+	Operator    ConditionOperator `json:"operator,omitempty"`
+	Value       interface{}       `json:"value,omitempty"`
+	All         []Condition       `json:"all,omitempty"`
+	Any         []Condition       `json:"any,omitempty"`
+}
+
+// validate checks the condition only references questions in earlier
+// sections or earlier in the same section, i.e. the keys in earlier
+func (c Condition) validate(earlier map[string]bool) error {
+	if len(c.All) > 0 || len(c.Any) > 0 {
+		if c.QuestionKey != "" {
+			return fmt.Errorf("a condition cannot combine question_key with all/any")
+		}
+		for _, nested := range append(append([]Condition(nil), c.All...), c.Any...) {
+			if err := nested.validate(earlier); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if c.QuestionKey == "" {
+		return fmt.Errorf("question_key is required")
+	}
+	if !earlier[c.QuestionKey] {
+		return fmt.Errorf("question %q must be defined before the condition that uses it", c.QuestionKey)
+	}
+
+	switch c.Operator {
+	case OperatorEquals, OperatorNotEquals, OperatorContains:
+		if c.Value == nil {
+			return fmt.Errorf("value is required for %s", c.Operator)
+		}
+	case OperatorIn:
+		if _, ok := c.Value.([]interface{}); !ok {
+			return fmt.Errorf("value must be a list for %s", c.Operator)
+		}
+	case OperatorAnswered:
+	default:
+		return fmt.Errorf("unsupported operator %q", c.Operator)
+	}
+
+	return nil
+}
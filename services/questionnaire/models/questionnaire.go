@@ -0,0 +1,251 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"security-questionnaire/pkg/models"
+)
+
+// QuestionType identifies how a question is answered
+type QuestionType string
+
+// Supported question types
+const (
+	QuestionTypeYesNo        QuestionType = "yes_no"
+	QuestionTypeSingleChoice QuestionType = "single_choice"
+	QuestionTypeMultiChoice  QuestionType = "multi_choice"
+	QuestionTypeText         QuestionType = "text"
+	QuestionTypeNumber       QuestionType = "number"
+	QuestionTypeDate         QuestionType = "date"
+)
+
+// Valid reports whether t is a supported question type
+func (t QuestionType) Valid() bool {
+	switch t {
+	case QuestionTypeYesNo, QuestionTypeSingleChoice, QuestionTypeMultiChoice,
+		QuestionTypeText, QuestionTypeNumber, QuestionTypeDate:
+		return true
+	}
+	return false
+}
+
+// Question is a single question within a section.
+// Key is stable across versions and is used as the answer key in Result.Data.
+type Question struct {
+	Key      string       `json:"key"`
+	Text     string       `json:"text"`
+	HelpText string       `json:"help_text,omitempty"`
+	Type     QuestionType `json:"type"`
+	Weight   int          `json:"weight"`
+	Required bool         `json:"required"`
+	Options  []string     `json:"options,omitempty"`
+	// MinLength and MaxLength bound text answers, in characters (0 means unbounded)
+	MinLength int `json:"min_length,omitempty"`
+	MaxLength int `json:"max_length,omitempty"`
+	// Min and Max bound number answers
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// AllowNA permits a "not applicable" answer, which must carry a justification
+	AllowNA bool `json:"allow_na,omitempty"`
+	// VisibleWhen hides the question unless the condition holds for earlier answers
+	VisibleWhen *Condition `json:"visible_when,omitempty"`
+	// ExpectedAnswers lists the answers that earn the question's weight when scoring.
+	// Questions without expected answers do not contribute to the score.
+	ExpectedAnswers []string `json:"expected_answers,omitempty"`
+}
+
+// Scored reports whether the question contributes to the score
+func (q Question) Scored() bool {
+	return q.Weight > 0 && len(q.ExpectedAnswers) > 0
+}
+
+// ScoringRule overrides the scoring fields of a single question
+type ScoringRule struct {
+	Weight          *int     `json:"weight,omitempty"`
+	ExpectedAnswers []string `json:"expected_answers,omitempty"`
+}
+
+// ApplyScoringRules returns a copy of the sections with weights and expected answers
+// replaced for the given question keys. Question text, types and order are untouched.
+func (s Sections) ApplyScoringRules(rules map[string]ScoringRule) (Sections, error) {
+	known := make(map[string]bool)
+	updated := make(Sections, len(s))
+	for i, section := range s {
+		section.Questions = append([]Question(nil), section.Questions...)
+		for j, q := range section.Questions {
+			known[q.Key] = true
+			rule, ok := rules[q.Key]
+			if !ok {
+				continue
+			}
+			if rule.Weight != nil {
+				if *rule.Weight < 0 {
+					return nil, fmt.Errorf("question %q: weight cannot be negative", q.Key)
+				}
+				q.Weight = *rule.Weight
+			}
+			if rule.ExpectedAnswers != nil {
+				q.ExpectedAnswers = rule.ExpectedAnswers
+			}
+			section.Questions[j] = q
+		}
+		updated[i] = section
+	}
+
+	for key := range rules {
+		if !known[key] {
+			return nil, fmt.Errorf("unknown question key %q", key)
+		}
+	}
+
+	return updated, nil
+}
+
+// Section is an ordered group of questions
+type Section struct {
+	Key         string     `json:"key"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	VisibleWhen *Condition `json:"visible_when,omitempty"`
+	Questions   []Question `json:"questions"`
+}
+
+// Sections is the ordered list of sections stored in a jsonb column
+type Sections []Section
+
+// Value implements driver.Valuer so sections are written as JSON
+func (s Sections) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sections: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner so jsonb values are read back into sections
+func (s *Sections) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("unsupported type for sections: %T", value)
+	}
+}
+
+// Questions returns every question across all sections in order
+func (s Sections) Questions() []Question {
+	var questions []Question
+	for _, section := range s {
+		questions = append(questions, section.Questions...)
+	}
+	return questions
+}
+
+// Validate checks that the sections form a publishable questionnaire
+func (s Sections) Validate() error {
+	if len(s) == 0 {
+		return fmt.Errorf("at least one section is required")
+	}
+
+	sectionKeys := make(map[string]bool)
+	questionKeys := make(map[string]bool)
+	for i, section := range s {
+		if section.Key == "" {
+			return fmt.Errorf("sections[%d]: key is required", i)
+		}
+		if sectionKeys[section.Key] {
+			return fmt.Errorf("sections[%d]: duplicate section key %q", i, section.Key)
+		}
+		sectionKeys[section.Key] = true
+
+		// Conditions may only depend on questions defined earlier, which rules out cycles
+		if section.VisibleWhen != nil {
+			if err := section.VisibleWhen.validate(questionKeys); err != nil {
+				return fmt.Errorf("section %q: visible_when: %w", section.Key, err)
+			}
+		}
+
+		if len(section.Questions) == 0 {
+			return fmt.Errorf("section %q: at least one question is required", section.Key)
+		}
+
+		for j, q := range section.Questions {
+			if q.Key == "" {
+				return fmt.Errorf("section %q question %d: key is required", section.Key, j)
+			}
+			if questionKeys[q.Key] {
+				return fmt.Errorf("question %q: duplicate question key", q.Key)
+			}
+			if q.VisibleWhen != nil {
+				if err := q.VisibleWhen.validate(questionKeys); err != nil {
+					return fmt.Errorf("question %q: visible_when: %w", q.Key, err)
+				}
+			}
+			questionKeys[q.Key] = true
+
+			if q.Text == "" {
+				return fmt.Errorf("question %q: text is required", q.Key)
+			}
+			if !q.Type.Valid() {
+				return fmt.Errorf("question %q: unsupported type %q", q.Key, q.Type)
+			}
+			if q.Weight < 0 {
+				return fmt.Errorf("question %q: weight cannot be negative", q.Key)
+			}
+			if (q.Type == QuestionTypeSingleChoice || q.Type == QuestionTypeMultiChoice) && len(q.Options) == 0 {
+				return fmt.Errorf("question %q: options are required for %s questions", q.Key, q.Type)
+			}
+			if q.MinLength < 0 || q.MaxLength < 0 || (q.MaxLength > 0 && q.MinLength > q.MaxLength) {
+				return fmt.Errorf("question %q: invalid length limits", q.Key)
+			}
+			if q.Min != nil && q.Max != nil && *q.Min > *q.Max {
+				return fmt.Errorf("question %q: min cannot be greater than max", q.Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Questionnaire holds the editable draft of a questionnaire definition
+type Questionnaire struct {
+	models.BaseModel
+	Name          string   `gorm:"column:name;not null" json:"name"`
+	Description   string   `gorm:"column:description;type:text" json:"description,omitempty"`
+	Sections      Sections `gorm:"column:sections;type:jsonb" json:"sections"`
+	LatestVersion int      `gorm:"column:latest_version;not null;default:0" json:"latest_version"`
+}
+
+// TableName specifies the table name for the Questionnaire model
+func (Questionnaire) TableName() string {
+	return "questionnaires"
+}
+
+// QuestionnaireVersion is an immutable, published snapshot of a questionnaire.
+// Results reference a version so answers are always read against the text that was shown.
+// Only scoring fields (weights and expected answers) may be changed after publishing.
+type QuestionnaireVersion struct {
+	models.BaseModel
+	QuestionnaireID string    `gorm:"column:questionnaire_id;type:uuid;not null;uniqueIndex:idx_questionnaire_version" json:"questionnaire_id"`
+	Version         int       `gorm:"column:version;not null;uniqueIndex:idx_questionnaire_version" json:"version"`
+	Name            string    `gorm:"column:name;not null" json:"name"`
+	Description     string    `gorm:"column:description;type:text" json:"description,omitempty"`
+	Sections        Sections  `gorm:"column:sections;type:jsonb;not null" json:"sections"`
+	PublishedAt     time.Time `gorm:"column:published_at;not null" json:"published_at"`
+}
+
+// TableName specifies the table name for the QuestionnaireVersion model
+func (QuestionnaireVersion) TableName() string {
+	return "questionnaire_versions"
+}
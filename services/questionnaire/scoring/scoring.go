@@ -0,0 +1,113 @@
+// Package scoring computes questionnaire scores from result answers.
+package scoring
+
+import (
+	"math"
+
+	"security-questionnaire/services/questionnaire/models"
+	"security-questionnaire/services/questionnaire/visibility"
+)
+
+// SectionScore is the score of a single section
+type SectionScore struct {
+	Key      string  `json:"key"`
+	Score    *int    `json:"score"`
+	Earned   float64 `json:"earned"`
+	Possible float64 `json:"possible"`
+}
+
+// Score is the outcome of scoring a set of answers.
+// Score is nil when no question in the questionnaire is scored.
+type Score struct {
+	Score    *int           `json:"score"`
+	Earned   float64        `json:"earned"`
+	Possible float64        `json:"possible"`
+	Sections []SectionScore `json:"sections"`
+}
+
+// SectionScores returns the section subscores keyed by section key
+func (s Score) SectionScores() map[string]interface{} {
+	scores := make(map[string]interface{}, len(s.Sections))
+	for _, section := range s.Sections {
+		if section.Score != nil {
+			scores[section.Key] = *section.Score
+		}
+	}
+	return scores
+}
+
+// Evaluate scores answers against the weights and expected answers of the sections.
+// Unanswered scored questions count as zero; hidden questions and questions
+// answered N/A are left out.
+func Evaluate(sections models.Sections, answers map[string]interface{}) Score {
+	var result Score
+	visible := visibility.Evaluate(sections, answers)
+	for _, section := range sections {
+		sectionScore := SectionScore{Key: section.Key}
+		for _, q := range section.Questions {
+			if !q.Scored() || !visible.QuestionVisible(q.Key) {
+				continue
+			}
+			if _, na := models.ParseNotApplicable(answers[q.Key]); na {
+				continue
+			}
+			weight := float64(q.Weight)
+			sectionScore.Possible += weight
+			sectionScore.Earned += weight * credit(q, answers[q.Key])
+		}
+		sectionScore.Score = percentage(sectionScore.Earned, sectionScore.Possible)
+
+		result.Earned += sectionScore.Earned
+		result.Possible += sectionScore.Possible
+		result.Sections = append(result.Sections, sectionScore)
+	}
+	result.Score = percentage(result.Earned, result.Possible)
+
+	return result
+}
+
+// credit returns the fraction (0..1) of a question's weight earned by an answer
+func credit(q models.Question, answer interface{}) float64 {
+	if answer == nil {
+		return 0
+	}
+
+	if q.Type == models.QuestionTypeMultiChoice {
+		selected := models.NormalizeAnswers(answer)
+		if len(selected) == 0 {
+			return 0
+		}
+		// Jaccard similarity between the selected and expected options
+		expected := make(map[string]bool, len(q.ExpectedAnswers))
+		union := make(map[string]bool)
+		for _, e := range q.ExpectedAnswers {
+			expected[models.NormalizeAnswer(e)] = true
+			union[models.NormalizeAnswer(e)] = true
+		}
+		matched := 0
+		for _, s := range selected {
+			if expected[s] {
+				matched++
+			}
+			union[s] = true
+		}
+		return float64(matched) / float64(len(union))
+	}
+
+	value := models.NormalizeAnswer(answer)
+	for _, e := range q.ExpectedAnswers {
+		if models.NormalizeAnswer(e) == value {
+			return 1
+		}
+	}
+	return 0
+}
+
+// percentage returns earned/possible as a rounded 0..100 score
+func percentage(earned, possible float64) *int {
+	if possible == 0 {
+		return nil
+	}
+	score := int(math.Round(earned / possible * 100))
+	return &score
+}
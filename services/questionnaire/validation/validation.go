@@ -0,0 +1,202 @@
+// Package validation checks result answers against a questionnaire definition.
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"security-questionnaire/services/questionnaire/models"
+	"security-questionnaire/services/questionnaire/visibility"
+)
+
+// DateLayout is the accepted format for date answers
+const DateLayout = "2006-01-02"
+
+// Error codes reported for failing answers
+const (
+	CodeUnknownQuestion       = "unknown_question"
+	CodeRequired              = "required"
+	CodeInvalidType           = "invalid_type"
+	CodeInvalidOption         = "invalid_option"
+	CodeTooShort              = "too_short"
+	CodeTooLong               = "too_long"
+	CodeOutOfRange            = "out_of_range"
+	CodeInvalidDate           = "invalid_date"
+	CodeNotApplicableDenied   = "na_not_allowed"
+	CodeJustificationRequired = "justification_required"
+)
+
+// FieldError describes why the answer to a question was rejected
+type FieldError struct {
+	QuestionKey string `json:"question_key"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+}
+
+// Errors is the list of failing answers
+type Errors []FieldError
+
+// Error implements the error interface
+func (e Errors) Error() string {
+	keys := make([]string, len(e))
+	for i, fe := range e {
+		keys[i] = fe.QuestionKey
+	}
+	return fmt.Sprintf("%d invalid answer(s): %s", len(e), strings.Join(keys, ", "))
+}
+
+// Options controls how strictly answers are validated
+type Options struct {
+	// RequireComplete reports missing answers to required questions, as on submission
+	RequireComplete bool
+}
+
+// Validate checks every answer against its question and returns all failures.
+// A nil answer is treated as unanswered. Questions hidden by their visible_when
+// condition are neither required nor validated.
+func Validate(sections models.Sections, answers map[string]interface{}, opts Options) Errors {
+	var errs Errors
+
+	visible := visibility.Evaluate(sections, answers)
+	known := make(map[string]bool)
+	for _, q := range sections.Questions() {
+		known[q.Key] = true
+		if !visible.QuestionVisible(q.Key) {
+			continue
+		}
+
+		answer, ok := answers[q.Key]
+		if !ok || answer == nil {
+			if opts.RequireComplete && q.Required {
+				errs = append(errs, FieldError{q.Key, CodeRequired, "An answer is required"})
+			}
+			continue
+		}
+
+		if fe := validateAnswer(q, answer); fe != nil {
+			errs = append(errs, *fe)
+		}
+	}
+
+	// Report unknown keys in a stable order
+	var unknown []string
+	for key := range answers {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		errs = append(errs, FieldError{key, CodeUnknownQuestion, "No question with this key exists in the questionnaire version"})
+	}
+
+	return errs
+}
+
+// validateAnswer checks a single non-nil answer against its question
+func validateAnswer(q models.Question, answer interface{}) *FieldError {
+	fail := func(code, format string, args ...interface{}) *FieldError {
+		return &FieldError{QuestionKey: q.Key, Code: code, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if na, ok := models.ParseNotApplicable(answer); ok {
+		if !q.AllowNA {
+			return fail(CodeNotApplicableDenied, "This question cannot be answered N/A")
+		}
+		if strings.TrimSpace(na.Justification) == "" {
+			return fail(CodeJustificationRequired, "A justification is required for N/A answers")
+		}
+		return nil
+	}
+
+	switch q.Type {
+	case models.QuestionTypeYesNo:
+		switch v := answer.(type) {
+		case bool:
+			return nil
+		case string:
+			if value := strings.ToLower(v); value == "yes" || value == "no" {
+				return nil
+			}
+		}
+		return fail(CodeInvalidType, "Expected yes or no")
+
+	case models.QuestionTypeSingleChoice:
+		value, ok := answer.(string)
+		if !ok {
+			return fail(CodeInvalidType, "Expected a single option")
+		}
+		if !contains(q.Options, value) {
+			return fail(CodeInvalidOption, "%q is not one of the allowed options", value)
+		}
+
+	case models.QuestionTypeMultiChoice:
+		values, ok := answer.([]interface{})
+		if !ok {
+			return fail(CodeInvalidType, "Expected a list of options")
+		}
+		seen := make(map[string]bool)
+		for _, item := range values {
+			value, ok := item.(string)
+			if !ok {
+				return fail(CodeInvalidType, "Expected a list of options")
+			}
+			if !contains(q.Options, value) {
+				return fail(CodeInvalidOption, "%q is not one of the allowed options", value)
+			}
+			if seen[value] {
+				return fail(CodeInvalidOption, "%q is selected more than once", value)
+			}
+			seen[value] = true
+		}
+
+	case models.QuestionTypeText:
+		value, ok := answer.(string)
+		if !ok {
+			return fail(CodeInvalidType, "Expected text")
+		}
+		length := utf8.RuneCountInString(value)
+		if q.MinLength > 0 && length < q.MinLength {
+			return fail(CodeTooShort, "Must be at least %d characters", q.MinLength)
+		}
+		if q.MaxLength > 0 && length > q.MaxLength {
+			return fail(CodeTooLong, "Must be at most %d characters", q.MaxLength)
+		}
+
+	case models.QuestionTypeNumber:
+		value, ok := answer.(float64)
+		if !ok {
+			return fail(CodeInvalidType, "Expected a number")
+		}
+		if q.Min != nil && value < *q.Min {
+			return fail(CodeOutOfRange, "Must be at least %v", *q.Min)
+		}
+		if q.Max != nil && value > *q.Max {
+			return fail(CodeOutOfRange, "Must be at most %v", *q.Max)
+		}
+
+	case models.QuestionTypeDate:
+		value, ok := answer.(string)
+		if !ok {
+			return fail(CodeInvalidType, "Expected a date")
+		}
+		if _, err := time.Parse(DateLayout, value); err != nil {
+			return fail(CodeInvalidDate, "Expected a date formatted as YYYY-MM-DD")
+		}
+	}
+
+	return nil
+}
+
+// contains reports whether value is one of options
+func contains(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Package visibility evaluates the visible_when conditions of a questionnaire
+// against a set of answers. Validation, scoring and exports share it so that
+// every consumer agrees on which questions were shown.
+package visibility
+
+import (
+	"security-questionnaire/services/questionnaire/models"
+)
+
+// Visibility records which sections and questions are shown for a set of answers
+type Visibility struct {
+	sections  map[string]bool
+	questions map[string]bool
+}
+
+// Evaluate resolves visibility for every section and question in order.
+// A question is hidden when its section is hidden, and answers to hidden
+// questions are ignored by later conditions.
+func Evaluate(sections models.Sections, answers map[string]interface{}) Visibility {
+	v := Visibility{
+		sections:  make(map[string]bool, len(sections)),
+		questions: make(map[string]bool),
+	}
+
+	visibleAnswers := make(map[string]interface{})
+	for _, section := range sections {
+		sectionVisible := section.VisibleWhen == nil || matches(*section.VisibleWhen, visibleAnswers)
+		v.sections[section.Key] = sectionVisible
+
+		for _, q := range section.Questions {
+			visible := sectionVisible && (q.VisibleWhen == nil || matches(*q.VisibleWhen, visibleAnswers))
+			v.questions[q.Key] = visible
+			if visible {
+				if answer, ok := answers[q.Key]; ok {
+					visibleAnswers[q.Key] = answer
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// SectionVisible reports whether the section with the given key is shown
+func (v Visibility) SectionVisible(key string) bool {
+	return v.sections[key]
+}
+
+// QuestionVisible reports whether the question with the given key is shown
+func (v Visibility) QuestionVisible(key string) bool {
+	return v.questions[key]
+}
+
+// VisibleQuestions returns the shown questions in questionnaire order
+func (v Visibility) VisibleQuestions(sections models.Sections) []models.Question {
+	var questions []models.Question
+	for _, q := range sections.Questions() {
+		if v.questions[q.Key] {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+// matches evaluates a condition against the answers of visible questions
+func matches(c models.Condition, answers map[string]interface{}) bool {
+	if len(c.All) > 0 {
+		for _, nested := range c.All {
+			if !matches(nested, answers) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(c.Any) > 0 {
+		for _, nested := range c.Any {
+			if matches(nested, answers) {
+				return true
+			}
+		}
+		return false
+	}
+
+	answer, answered := answers[c.QuestionKey]
+	answered = answered && answer != nil
+	if c.Operator == models.OperatorAnswered {
+		return answered
+	}
+	if !answered {
+		return false
+	}
+	// N/A answers never match a value comparison
+	if _, na := models.ParseNotApplicable(answer); na {
+		return c.Operator == models.OperatorNotEquals
+	}
+
+	switch c.Operator {
+	case models.OperatorEquals:
+		return models.NormalizeAnswer(answer) == models.NormalizeAnswer(c.Value)
+	case models.OperatorNotEquals:
+		return models.NormalizeAnswer(answer) != models.NormalizeAnswer(c.Value)
+	case models.OperatorIn:
+		value := models.NormalizeAnswer(answer)
+		for _, candidate := range models.NormalizeAnswers(c.Value) {
+			if candidate == value {
+				return true
+			}
+		}
+	case models.OperatorContains:
+		value := models.NormalizeAnswer(c.Value)
+		for _, selected := range models.NormalizeAnswers(answer) {
+			if selected == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/services/result/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// actorFromContext returns the lifecycle actor the caller acts as: analysts
+// review results, vendors answer them
+func actorFromContext(ctx context.Context) models.Actor {
+	switch auth.FromContext(ctx).Role {
+	case auth.RoleAdmin:
+		return models.ActorAdmin
+	case auth.RoleAnalyst:
+		return models.ActorReviewer
+	default:
+		return models.ActorRespondent
+	}
+}
+
+// denyResult audits and refuses access to a result the caller does not own
+func denyResult(ctx context.Context, result *models.Result) (events.APIGatewayV2HTTPResponse, error) {
+	return auth.Deny(ctx, auth.Resource{Type: "result", ID: result.ID}, "result belongs to another owner")
+}
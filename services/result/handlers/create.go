@@ -3,48 +3,150 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/validation"
+	"security-questionnaire/services/result/models"
 
 	"github.com/aws/aws-lambda-go/events"
 )
 
 // CreateResultRequest represents the request body for creating a result
 type CreateResultRequest struct {
-	QuestionnaireID string                 `json:"questionnaire_id"`
-	Data            map[string]interface{} `json:"data"`
-	Status          string                 `json:"status"`
+	QuestionnaireID      string                 `json:"questionnaire_id"`
+	QuestionnaireVersion int                    `json:"questionnaire_version,omitempty"` // defaults to latest published
+	Data                 map[string]interface{} `json:"data"`
+	Status               string                 `json:"status"`
+	// OwnerID is the vendor the result is answered for; analysts and admins
+	// may create results on behalf of a vendor, others own what they create
+	OwnerID string `json:"owner_id,omitempty"`
+	// Evidence is attached in the same transaction as the result is created
+	Evidence []AttachEvidenceRequest `json:"evidence,omitempty"`
 }
 
 // CreateResultResponse represents the response for creating a result
 type CreateResultResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *models.Result `json:"data,omitempty"`
 }
 
 // HandleCreate handles the creation of a new result
 func HandleCreate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
 	// Parse request body
 	var req CreateResultRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return ErrorResponse(400, "Invalid request body")
+		return respond.InvalidBody(ctx)
 	}
 
 	// Validate required fields
 	if req.QuestionnaireID == "" {
-		return ErrorResponse(400, "questionnaire_id is required")
+		return respond.Required(ctx, "questionnaire_id is required", "questionnaire_id")
+	}
+	if req.QuestionnaireVersion < 0 {
+		return respond.InvalidFields(ctx, "questionnaire_version must be a positive integer",
+			respond.FieldError{Field: "questionnaire_version", Code: apierror.FieldOutOfRange, Message: "must be a positive integer"})
+	}
+
+	status := models.Status(req.Status)
+	if status == "" {
+		status = models.DefaultStatus
+	}
+	if !status.Initial() {
+		return respond.InvalidFields(ctx, fmt.Sprintf("Results must be created as %s or %s", models.StatusDraft, models.StatusInProgress),
+			respond.FieldError{Field: "status", Code: apierror.FieldInvalid, Message: fmt.Sprintf("must be %s or %s", models.StatusDraft, models.StatusInProgress)})
+	}
+
+	identity := auth.FromContext(ctx)
+	ownerID := identity.Subject
+	if req.OwnerID != "" && req.OwnerID != identity.Subject {
+		if identity.Scoped() {
+			return auth.Deny(ctx, auth.Resource{Type: "result"}, "cannot create results for another owner")
+		}
+		ownerID = req.OwnerID
 	}
 
-	// TODO: Implement result creation logic
-	// For now, return a placeholder response
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
 
+	// Results must point at a published version of the questionnaire
+	version, err := loadQuestionnaireVersion(ctx, dbService, req.QuestionnaireID, req.QuestionnaireVersion)
+	if errors.Is(err, database.ErrNotFound) {
+		return respond.Fail(ctx, 422, CodeQuestionnaireNotPublished, "questionnaire_id does not reference a published questionnaire version")
+	}
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to load questionnaire version")
+	}
+
+	// Validate answers against the questionnaire version
+	if errs := validation.Validate(version.Sections, req.Data, validation.Options{}); len(errs) > 0 {
+		return respond.ValidationError(ctx, "One or more answers are invalid", answerErrors(errs))
+	}
+
+	// Validate evidence before anything is written
+	for i, evidence := range req.Evidence {
+		if missing := evidence.missingFields(fmt.Sprintf("evidence[%d].", i)); len(missing) > 0 {
+			return respond.Required(ctx, "evidence entries require question_key and document_ids", missing...)
+		}
+		if err := checkEvidence(ctx, dbService, version, evidence); err != nil {
+			if errors.Is(err, errInvalidEvidence) {
+				return respond.Fail(ctx, 422, CodeInvalidEvidence, err.Error())
+			}
+			return respond.ErrorFor(ctx, err, "Document", "Failed to look up documents")
+		}
+	}
+
+	// Create result record in database
+	result := &models.Result{
+		QuestionnaireID:      version.QuestionnaireID,
+		QuestionnaireVersion: version.Version,
+		Data:                 req.Data,
+		Status:               status,
+		StatusHistory: models.StatusHistory{
+			{To: status, Actor: actorFromContext(ctx), By: identity.Subject, At: time.Now().UTC()},
+		},
+		OwnerID: ownerID,
+	}
+	applyScore(result, version)
+
+	// The result and its evidence are created together or not at all
+	err = dbService.WithTransaction(ctx, func(tx *database.DatabaseService) error {
+		if err := database.NewRepository[models.Result](tx).Create(ctx, result); err != nil {
+			return err
+		}
+		for _, evidence := range req.Evidence {
+			if err := attachEvidence(ctx, tx, result.ID, evidence); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to create result record")
+	}
+
+	// Return success response
 	response := CreateResultResponse{
 		Success: true,
-		Message: "Result created successfully (placeholder)",
-		Data: map[string]interface{}{
-			"questionnaire_id": req.QuestionnaireID,
-			"status":           req.Status,
-		},
+		Message: "Result created successfully",
+		Data:    result,
 	}
 
-	return SuccessResponse(201, response)
+	return respond.Success(201, response)
 }
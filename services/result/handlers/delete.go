@@ -3,6 +3,11 @@ package handlers
 import (
 	"context"
 
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/result/models"
+
 	"github.com/aws/aws-lambda-go/events"
 )
 
@@ -14,18 +19,34 @@ type DeleteResultResponse struct {
 
 // HandleDelete handles deleting a result by ID
 func HandleDelete(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
 	// Get result ID from path parameters
 	resultID := request.PathParameters["id"]
 	if resultID == "" {
-		return ErrorResponse(400, "Result ID is required")
+		return respond.Error(ctx, 400, "Result ID is required")
 	}
 
-	// TODO: Implement result deletion logic
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Soft-delete result (BaseModel.DeletedAt is set, the row is kept)
+	if err := database.NewRepository[models.Result](dbService).Delete(ctx, resultID); err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to delete result")
+	}
 
+	// Return success response
 	response := DeleteResultResponse{
 		Success: true,
-		Message: "Result deleted successfully (placeholder)",
+		Message: "Result deleted successfully",
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/validation"
+)
+
+// Error codes specific to the result service
+const (
+	// CodeResultLocked: the answers or evidence of a result in its current status cannot change
+	CodeResultLocked = "result_locked"
+	// CodeInvalidTransition: the requested status change is not allowed
+	CodeInvalidTransition = "invalid_transition"
+	// CodeInvalidEvidence: evidence refers to unknown questions or documents
+	CodeInvalidEvidence = "invalid_evidence"
+	// CodeQuestionnaireNotPublished: the result refers to an unpublished questionnaire version
+	CodeQuestionnaireNotPublished = "questionnaire_not_published"
+)
+
+// answerErrors reports failing answers as errors of the data.<question_key> fields
+func answerErrors(errs validation.Errors) []respond.FieldError {
+	fieldErrors := make([]respond.FieldError, len(errs))
+	for i, fe := range errs {
+		fieldErrors[i] = respond.FieldError{Field: "data." + fe.QuestionKey, Code: fe.Code, Message: fe.Message}
+	}
+	return fieldErrors
+}
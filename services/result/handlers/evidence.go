@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/pkg/storage"
+	documentmodels "security-questionnaire/services/document/models"
+	questionnairemodels "security-questionnaire/services/questionnaire/models"
+	"security-questionnaire/services/result/models"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/google/uuid"
+)
+
+// evidenceURLExpiration is how long expanded evidence download URLs stay valid
+const evidenceURLExpiration = 1 * time.Hour
+
+// AttachEvidenceRequest represents the request body for attaching evidence to a question
+type AttachEvidenceRequest struct {
+	QuestionKey string   `json:"question_key"`
+	DocumentIDs []string `json:"document_ids"`
+}
+
+// missingFields returns the names of the required fields left empty,
+// each prefixed with prefix
+func (r AttachEvidenceRequest) missingFields(prefix string) []string {
+	var missing []string
+	if r.QuestionKey == "" {
+		missing = append(missing, prefix+"question_key")
+	}
+	if len(r.DocumentIDs) == 0 {
+		missing = append(missing, prefix+"document_ids")
+	}
+	return missing
+}
+
+// EvidenceDetail is an evidence link, optionally expanded with its document
+type EvidenceDetail struct {
+	models.Evidence
+	Document    *documentmodels.Document `json:"document,omitempty"`
+	DownloadURL string                   `json:"download_url,omitempty"`
+}
+
+// EvidenceResponse represents the response for attaching or listing evidence
+type EvidenceResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    []EvidenceDetail `json:"data"`
+}
+
+// DetachEvidenceResponse represents the response for removing evidence
+type DetachEvidenceResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// HandleAttachEvidence links one or more documents to a question of a result
+func HandleAttachEvidence(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get result ID from path parameters
+	resultID := request.PathParameters["id"]
+	if resultID == "" {
+		return respond.Error(ctx, 400, "Result ID is required")
+	}
+
+	// Parse request body
+	var req AttachEvidenceRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Validate required fields
+	if missing := req.missingFields(""); len(missing) > 0 {
+		return respond.Required(ctx, "question_key and document_ids are required", missing...)
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Lock the result so a concurrent update cannot submit it while its
+	// evidence is being attached
+	var response events.APIGatewayV2HTTPResponse
+	err = dbService.WithTransaction(ctx, func(tx *database.DatabaseService) error {
+		var err error
+		response, err = attachResultEvidence(ctx, tx, cfg, resultID, req)
+		return err
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to attach evidence")
+	}
+	return response, nil
+}
+
+// attachResultEvidence attaches the evidence of req to the locked result.
+// Database errors are returned so the transaction rolls back; every other
+// outcome is a response.
+func attachResultEvidence(ctx context.Context, tx *database.DatabaseService, cfg *config.Config, resultID string, req AttachEvidenceRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Evidence is part of the answers, so it follows the same read-only rule
+	result, err := database.NewRepository[models.Result](tx).First(ctx, database.ListOptions{
+		Filters:   []database.Filter{database.Eq("id", resultID)},
+		ForUpdate: true,
+	})
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+	if !auth.FromContext(ctx).Owns(result.OwnerID) {
+		return denyResult(ctx, &result)
+	}
+	if !result.Status.Editable() {
+		return respond.Fail(ctx, 409, CodeResultLocked, fmt.Sprintf("Evidence is read-only once a result is %s", result.Status))
+	}
+
+	// The question must exist in the result's questionnaire version
+	version, err := loadQuestionnaireVersion(ctx, tx, result.QuestionnaireID, result.QuestionnaireVersion)
+	if errors.Is(err, database.ErrNotFound) {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to load questionnaire version")
+	}
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+	if err := checkEvidence(ctx, tx, version, req); err != nil {
+		if errors.Is(err, errInvalidEvidence) {
+			return respond.Fail(ctx, 422, CodeInvalidEvidence, err.Error())
+		}
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+
+	if err := attachEvidence(ctx, tx, result.ID, req); err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+
+	evidence, err := loadEvidence(ctx, tx, cfg, result.ID, false)
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+
+	// Return success response
+	response := EvidenceResponse{
+		Success: true,
+		Message: "Evidence attached successfully",
+		Data:    evidence,
+	}
+
+	return respond.Success(201, response)
+}
+
+// HandleListEvidence lists the evidence of a result.
+// Pass ?expand=documents to include document metadata and download URLs.
+func HandleListEvidence(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get result ID from path parameters
+	resultID := request.PathParameters["id"]
+	if resultID == "" {
+		return respond.Error(ctx, 400, "Result ID is required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	result, err := database.NewRepository[models.Result](dbService).Get(ctx, resultID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to get result")
+	}
+	if !auth.FromContext(ctx).Owns(result.OwnerID) {
+		return denyResult(ctx, &result)
+	}
+
+	expand := request.QueryStringParameters["expand"] == "documents"
+	evidence, err := loadEvidence(ctx, dbService, cfg, result.ID, expand)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Evidence", "Failed to list evidence")
+	}
+
+	// Return success response
+	response := EvidenceResponse{
+		Success: true,
+		Message: "Evidence retrieved successfully",
+		Data:    evidence,
+	}
+
+	return respond.Success(200, response)
+}
+
+// HandleDetachEvidence removes a single evidence link from a result
+func HandleDetachEvidence(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Get result and evidence IDs from path parameters
+	resultID := request.PathParameters["id"]
+	evidenceID := request.PathParameters["evidenceId"]
+	if resultID == "" || evidenceID == "" {
+		return respond.Error(ctx, 400, "Result ID and evidence ID are required")
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	result, err := database.NewRepository[models.Result](dbService).Get(ctx, resultID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to get result")
+	}
+	if !auth.FromContext(ctx).Owns(result.OwnerID) {
+		return denyResult(ctx, &result)
+	}
+	if !result.Status.Editable() {
+		return respond.Fail(ctx, 409, CodeResultLocked, fmt.Sprintf("Evidence is read-only once a result is %s", result.Status))
+	}
+
+	// Links are removed outright so the document can be deleted afterwards
+	deleted, err := database.NewRepository[models.Evidence](dbService).Purge(ctx,
+		database.Eq("id", evidenceID),
+		database.Eq("result_id", result.ID),
+	)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Evidence", "Failed to remove evidence")
+	}
+	if deleted == 0 {
+		return respond.Error(ctx, 404, "Evidence not found")
+	}
+
+	// Return success response
+	response := DetachEvidenceResponse{
+		Success: true,
+		Message: "Evidence removed successfully",
+	}
+
+	return respond.Success(200, response)
+}
+
+// loadEvidence lists the evidence of a result ordered by question key.
+// When expand is set, each link carries its document and a pre-signed download URL.
+func loadEvidence(ctx context.Context, dbService *database.DatabaseService, cfg *config.Config, resultID string, expand bool) ([]EvidenceDetail, error) {
+	links, err := database.NewRepository[models.Evidence](dbService).List(ctx, database.ListOptions{
+		Filters: []database.Filter{database.Eq("result_id", resultID)},
+		Sort:    []database.Sort{database.Asc("question_key"), database.Asc("created_at")},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]EvidenceDetail, len(links))
+	for i, link := range links {
+		details[i] = EvidenceDetail{Evidence: link}
+	}
+	if !expand || len(links) == 0 {
+		return details, nil
+	}
+
+	documentIDs := make([]string, len(links))
+	for i, link := range links {
+		documentIDs[i] = link.DocumentID
+	}
+
+	documents, err := database.NewRepository[documentmodels.Document](dbService).List(ctx, database.ListOptions{
+		Filters: []database.Filter{database.In("id", documentIDs)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*documentmodels.Document, len(documents))
+	for i := range documents {
+		byID[documents[i].ID] = &documents[i]
+	}
+
+	store, err := storage.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range details {
+		doc, ok := byID[details[i].DocumentID]
+		if !ok {
+			continue
+		}
+		details[i].Document = doc
+		url, err := store.GetFileURL(ctx, doc.S3Key, evidenceURLExpiration)
+		if err != nil {
+			return nil, err
+		}
+		details[i].DownloadURL = url
+	}
+
+	return details, nil
+}
+
+// errInvalidEvidence marks evidence that references an unknown question or document
+var errInvalidEvidence = errors.New("invalid evidence")
+
+// checkEvidence verifies that the question exists in the questionnaire
+// version and that every document exists and has a confirmed upload.
+// Callers scoped to their own records may only attach their own documents.
+func checkEvidence(ctx context.Context, dbService *database.DatabaseService, version *questionnairemodels.QuestionnaireVersion, req AttachEvidenceRequest) error {
+	if !hasQuestion(version.Sections.Questions(), req.QuestionKey) {
+		return fmt.Errorf("%w: unknown question key %q", errInvalidEvidence, req.QuestionKey)
+	}
+
+	// Checked up front: a malformed UUID would abort the transaction
+	documentIDs := uniqueStrings(req.DocumentIDs)
+	for _, id := range documentIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("%w: document_id %q does not exist", errInvalidEvidence, id)
+		}
+	}
+	filters := []database.Filter{
+		database.In("id", documentIDs),
+		database.Eq("status", documentmodels.StatusActive),
+	}
+	if identity := auth.FromContext(ctx); identity.Scoped() {
+		filters = append(filters, database.Eq("owner_id", identity.Subject))
+	}
+	found, err := database.NewRepository[documentmodels.Document](dbService).Count(ctx, filters...)
+	if err != nil {
+		return err
+	}
+	if int(found) != len(documentIDs) {
+		return fmt.Errorf("%w: one or more document_ids do not exist", errInvalidEvidence)
+	}
+	return nil
+}
+
+// attachEvidence links the documents to a question of the result.
+// Attaching the same document twice is a no-op.
+func attachEvidence(ctx context.Context, dbService *database.DatabaseService, resultID string, req AttachEvidenceRequest) error {
+	documentIDs := uniqueStrings(req.DocumentIDs)
+	links := make([]models.Evidence, 0, len(documentIDs))
+	for _, documentID := range documentIDs {
+		links = append(links, models.Evidence{
+			ResultID:    resultID,
+			QuestionKey: req.QuestionKey,
+			DocumentID:  documentID,
+		})
+	}
+	return database.NewRepository[models.Evidence](dbService).CreateIgnoringConflicts(ctx, links)
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
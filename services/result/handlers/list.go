@@ -3,27 +3,86 @@ package handlers
 import (
 	"context"
 
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/query"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/result/models"
+
 	"github.com/aws/aws-lambda-go/events"
 )
 
 // ListResultsResponse represents the response for listing results
 type ListResultsResponse struct {
-	Success bool          `json:"success"`
-	Message string        `json:"message"`
-	Data    []interface{} `json:"data"`
-	Total   int64         `json:"total"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Data       []models.Result `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	PrevCursor string          `json:"prev_cursor,omitempty"`
+	Total      *int64          `json:"total,omitempty"`
+	Limit      int             `json:"limit"`
+}
+
+// listSpec lists the filter and sort parameters accepted by HandleList.
+// completed_at is stored as Unix seconds.
+var listSpec = query.Spec{
+	Params: []query.Param{
+		{Name: "questionnaire_id", Column: "questionnaire_id", Operator: database.OpEq},
+		{Name: "status", Column: "status", Operator: database.OpIn, Values: []string{
+			string(models.StatusDraft), string(models.StatusInProgress), string(models.StatusSubmitted),
+			string(models.StatusUnderReview), string(models.StatusApproved), string(models.StatusRejected),
+			string(models.StatusArchived),
+		}},
+		{Name: "min_score", Column: "score", Operator: database.OpGte, Type: query.Int},
+		{Name: "max_score", Column: "score", Operator: database.OpLte, Type: query.Int},
+		{Name: "completed_after", Column: "completed_at", Operator: database.OpGte, Type: query.UnixTime},
+		{Name: "completed_before", Column: "completed_at", Operator: database.OpLt, Type: query.UnixTime},
+	},
+	Sortable: []string{"created_at", "questionnaire_id"},
 }
 
-// HandleList handles listing all results
+// HandleList handles listing results with filters, sorting and cursor pagination
 func HandleList(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
-	// TODO: Implement result listing logic
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse pagination, filter and sort parameters
+	pageOpts, err := query.Parse(request.QueryStringParameters, listSpec)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Invalid query")
+	}
+
+	// Vendors only see their own results
+	if identity := auth.FromContext(ctx); identity.Scoped() {
+		pageOpts.Filters = append(pageOpts.Filters, database.Eq("owner_id", identity.Subject))
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Get one page of results
+	page, err := database.NewRepository[models.Result](dbService).Page(ctx, pageOpts)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to list results")
+	}
 
+	// Return success response
 	response := ListResultsResponse{
-		Success: true,
-		Message: "Results retrieved successfully (placeholder)",
-		Data:    []interface{}{},
-		Total:   0,
+		Success:    true,
+		Message:    "Results retrieved successfully",
+		Data:       page.Items,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Total:      page.Total,
+		Limit:      page.Limit,
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"security-questionnaire/pkg/database"
+	pkgmodels "security-questionnaire/pkg/models"
+	questionnairemodels "security-questionnaire/services/questionnaire/models"
+	"security-questionnaire/services/questionnaire/scoring"
+	"security-questionnaire/services/result/models"
+)
+
+// loadQuestionnaireVersion fetches a published questionnaire version.
+// A version of 0 selects the latest published version.
+func loadQuestionnaireVersion(ctx context.Context, dbService *database.DatabaseService, questionnaireID string, version int) (*questionnairemodels.QuestionnaireVersion, error) {
+	filters := []database.Filter{database.Eq("questionnaire_id", questionnaireID)}
+	if version > 0 {
+		filters = append(filters, database.Eq("version", version))
+	}
+
+	v, err := database.NewRepository[questionnairemodels.QuestionnaireVersion](dbService).First(ctx, database.ListOptions{
+		Filters: filters,
+		Sort:    []database.Sort{database.Desc("version")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questionnaire version: %w", err)
+	}
+	return &v, nil
+}
+
+// applyScore scores the result's answers and stores the outcome on the result
+func applyScore(result *models.Result, version *questionnairemodels.QuestionnaireVersion) {
+	score := scoring.Evaluate(version.Sections, result.Data)
+	result.Score = score.Score
+	result.SectionScores = pkgmodels.JSONMap(score.SectionScores())
+}
+
+// scoreUpdates returns the column updates that persist the result's score
+func scoreUpdates(result *models.Result) map[string]interface{} {
+	return map[string]interface{}{
+		"score":          result.Score,
+		"section_scores": result.SectionScores,
+	}
+}
+
+// hasQuestion reports whether a question with the given key exists
+func hasQuestion(questions []questionnairemodels.Question, key string) bool {
+	for _, q := range questions {
+		if q.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -3,33 +3,68 @@ package handlers
 import (
 	"context"
 
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/result/models"
+
 	"github.com/aws/aws-lambda-go/events"
 )
 
 // ReadResultResponse represents the response for reading a result
 type ReadResultResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *models.Result `json:"data,omitempty"`
+	// Evidence is included when the request passes ?expand=evidence
+	Evidence []EvidenceDetail `json:"evidence,omitempty"`
 }
 
-// HandleRead handles reading a result by ID
+// HandleRead handles reading a result by ID.
+// Pass ?expand=evidence to include evidence documents and download URLs.
 func HandleRead(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
 	// Get result ID from path parameters
 	resultID := request.PathParameters["id"]
 	if resultID == "" {
-		return ErrorResponse(400, "Result ID is required")
+		return respond.Error(ctx, 400, "Result ID is required")
 	}
 
-	// TODO: Implement result retrieval logic
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
 
+	// Get result from database
+	result, err := database.NewRepository[models.Result](dbService).Get(ctx, resultID)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to get result")
+	}
+	if !auth.FromContext(ctx).Owns(result.OwnerID) {
+		return denyResult(ctx, &result)
+	}
+
+	// Return success response
 	response := ReadResultResponse{
 		Success: true,
-		Message: "Result retrieved successfully (placeholder)",
-		Data: map[string]interface{}{
-			"id": resultID,
-		},
+		Message: "Result retrieved successfully",
+		Data:    &result,
+	}
+
+	if request.QueryStringParameters["expand"] == "evidence" {
+		evidence, err := loadEvidence(ctx, dbService, cfg, result.ID, true)
+		if err != nil {
+			return respond.ErrorFor(ctx, err, "Evidence", "Failed to load evidence")
+		}
+		response.Evidence = evidence
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/database"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/result/models"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// recomputeBatchSize is the number of results re-scored per query
+const recomputeBatchSize = 100
+
+// RecomputeScoresRequest represents the request body for re-scoring results
+type RecomputeScoresRequest struct {
+	QuestionnaireID      string `json:"questionnaire_id"`
+	QuestionnaireVersion int    `json:"questionnaire_version"`
+}
+
+// RecomputeScoresResponse represents the response for re-scoring results
+type RecomputeScoresResponse struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	Rescored int    `json:"rescored"`
+}
+
+// HandleRecompute re-scores every result of a questionnaire version.
+// Call it after editing the version's scoring rules.
+func HandleRecompute(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
+	// Parse request body
+	var req RecomputeScoresRequest
+	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
+		return respond.InvalidBody(ctx)
+	}
+
+	// Validate required fields
+	var missing []string
+	if req.QuestionnaireID == "" {
+		missing = append(missing, "questionnaire_id")
+	}
+	if req.QuestionnaireVersion <= 0 {
+		missing = append(missing, "questionnaire_version")
+	}
+	if len(missing) > 0 {
+		return respond.Required(ctx, "questionnaire_id and questionnaire_version are required", missing...)
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	version, err := loadQuestionnaireVersion(ctx, dbService, req.QuestionnaireID, req.QuestionnaireVersion)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to load questionnaire version")
+	}
+
+	// Re-score results in batches to bound memory use
+	rescored := 0
+	repo := database.NewRepository[models.Result](dbService)
+	filters := []database.Filter{
+		database.Eq("questionnaire_id", version.QuestionnaireID),
+		database.Eq("questionnaire_version", version.Version),
+	}
+	err = repo.InBatches(ctx, filters, recomputeBatchSize, func(results []models.Result) error {
+		for i := range results {
+			applyScore(&results[i], version)
+			if err := repo.Patch(ctx, &results[i], scoreUpdates(&results[i])); err != nil {
+				return err
+			}
+			rescored++
+		}
+		return nil
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to recompute scores")
+	}
+
+	// Return success response
+	response := RecomputeScoresResponse{
+		Success:  true,
+		Message:  "Scores recomputed successfully",
+		Rescored: rescored,
+	}
+
+	return respond.Success(200, response)
+}
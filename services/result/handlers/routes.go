@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/router"
+)
+
+// Register adds the result service's endpoints to r with the permission each requires
+func Register(r *router.Router) {
+	r.POST("/results", HandleCreate).Named("results.create").With(auth.RequiredPermission, auth.ResultsWrite)
+	r.GET("/results", HandleList).Named("results.list").With(auth.RequiredPermission, auth.ResultsRead)
+	r.POST("/results/recompute", HandleRecompute).Named("results.recompute").With(auth.RequiredPermission, auth.ResultsRecompute)
+	r.GET("/results/{id}", HandleRead).Named("results.read").With(auth.RequiredPermission, auth.ResultsRead)
+	r.PUT("/results/{id}", HandleUpdate).Named("results.update").With(auth.RequiredPermission, auth.ResultsWrite)
+	r.DELETE("/results/{id}", HandleDelete).Named("results.delete").With(auth.RequiredPermission, auth.ResultsDelete)
+
+	// Evidence links
+	r.POST("/results/{id}/evidence", HandleAttachEvidence).Named("results.evidence.attach").With(auth.RequiredPermission, auth.ResultsWrite)
+	r.GET("/results/{id}/evidence", HandleListEvidence).Named("results.evidence.list").With(auth.RequiredPermission, auth.ResultsRead)
+	r.DELETE("/results/{id}/evidence/{evidenceId}", HandleDetachEvidence).Named("results.evidence.detach").With(auth.RequiredPermission, auth.ResultsWrite)
+}
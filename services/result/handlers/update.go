@@ -3,6 +3,18 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"security-questionnaire/config"
+	"security-questionnaire/pkg/apierror"
+	"security-questionnaire/pkg/auth"
+	"security-questionnaire/pkg/database"
+	pkgmodels "security-questionnaire/pkg/models"
+	"security-questionnaire/pkg/respond"
+	"security-questionnaire/services/questionnaire/validation"
+	"security-questionnaire/services/result/models"
 
 	"github.com/aws/aws-lambda-go/events"
 )
@@ -15,34 +27,133 @@ type UpdateResultRequest struct {
 
 // UpdateResultResponse represents the response for updating a result
 type UpdateResultResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *models.Result `json:"data,omitempty"`
 }
 
-// HandleUpdate handles updating a result
+// HandleUpdate handles updating a result's answers and moving it through its lifecycle
 func HandleUpdate(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return respond.Internal(ctx, err, "Configuration error")
+	}
+
 	// Get result ID from path parameters
 	resultID := request.PathParameters["id"]
 	if resultID == "" {
-		return ErrorResponse(400, "Result ID is required")
+		return respond.Error(ctx, 400, "Result ID is required")
 	}
 
 	// Parse request body
 	var req UpdateResultRequest
 	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return ErrorResponse(400, "Invalid request body")
+		return respond.InvalidBody(ctx)
+	}
+
+	if req.Data == nil && req.Status == nil {
+		return respond.Error(ctx, 400, "No fields to update")
+	}
+	if req.Status != nil && !models.Status(*req.Status).Valid() {
+		return respond.InvalidFields(ctx, fmt.Sprintf("Unknown status %q", *req.Status),
+			respond.FieldError{Field: "status", Code: apierror.FieldInvalid, Message: "unknown status"})
+	}
+
+	// Initialize database service
+	dbService, err := database.GetDatabaseService(ctx, cfg)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Database", "Failed to initialize database service")
+	}
+
+	// Lock the result while the change is checked against its lifecycle, so
+	// concurrent transitions are applied one after the other
+	var response events.APIGatewayV2HTTPResponse
+	err = dbService.WithTransaction(ctx, func(tx *database.DatabaseService) error {
+		var err error
+		response, err = updateResult(ctx, tx, resultID, req)
+		return err
+	})
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Result", "Failed to update result")
+	}
+	return response, nil
+}
+
+// updateResult applies req to the locked result. Database errors are
+// returned so the transaction rolls back; every other outcome is a response.
+func updateResult(ctx context.Context, tx *database.DatabaseService, resultID string, req UpdateResultRequest) (events.APIGatewayV2HTTPResponse, error) {
+	results := database.NewRepository[models.Result](tx)
+	result, err := results.First(ctx, database.ListOptions{
+		Filters:   []database.Filter{database.Eq("id", resultID)},
+		ForUpdate: true,
+	})
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+	if !auth.FromContext(ctx).Owns(result.OwnerID) {
+		return denyResult(ctx, &result)
 	}
 
-	// TODO: Implement result update logic
+	// Build updates map (only include fields that are provided)
+	updates := make(map[string]interface{})
+	if req.Data != nil {
+		if !result.Status.Editable() {
+			return respond.Fail(ctx, 409, CodeResultLocked, fmt.Sprintf("Answers are read-only once a result is %s", result.Status))
+		}
+		result.Data = req.Data
+		updates["data"] = pkgmodels.JSONMap(req.Data)
+	}
+
+	if req.Status != nil && models.Status(*req.Status) != result.Status {
+		err := result.Transition(models.Status(*req.Status), actorFromContext(ctx), auth.FromContext(ctx).Subject, time.Now().UTC())
+		var transitionErr *models.TransitionError
+		if errors.As(err, &transitionErr) && transitionErr.Allowed {
+			// The transition exists, but the caller's role may not trigger it
+			return auth.Deny(ctx, auth.Resource{Type: "result", ID: result.ID}, err.Error())
+		}
+		if err != nil {
+			return respond.Fail(ctx, 409, CodeInvalidTransition, err.Error())
+		}
+		updates["status"] = result.Status
+		updates["status_history"] = result.StatusHistory
+		updates["completed_at"] = result.CompletedAt
+	}
+
+	if len(updates) == 0 {
+		return respond.Error(ctx, 400, "No fields to update")
+	}
+
+	version, err := loadQuestionnaireVersion(ctx, tx, result.QuestionnaireID, result.QuestionnaireVersion)
+	if err != nil {
+		return respond.ErrorFor(ctx, err, "Questionnaire version", "Failed to load questionnaire version")
+	}
+
+	// Validate answers; submission additionally requires every required answer
+	if req.Data != nil || result.Status == models.StatusSubmitted {
+		opts := validation.Options{RequireComplete: result.Status == models.StatusSubmitted}
+		if errs := validation.Validate(version.Sections, result.Data, opts); len(errs) > 0 {
+			return respond.ValidationError(ctx, "One or more answers are invalid", answerErrors(errs))
+		}
+	}
+
+	// Re-score the result against its questionnaire version
+	applyScore(&result, version)
+	for column, value := range scoreUpdates(&result) {
+		updates[column] = value
+	}
+
+	// Update result in database
+	if err := results.Patch(ctx, &result, updates); err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
 
+	// Return success response
 	response := UpdateResultResponse{
 		Success: true,
-		Message: "Result updated successfully (placeholder)",
-		Data: map[string]interface{}{
-			"id": resultID,
-		},
+		Message: "Result updated successfully",
+		Data:    &result,
 	}
 
-	return SuccessResponse(200, response)
+	return respond.Success(200, response)
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"security-questionnaire/pkg/models"
+)
+
+// Evidence links a supporting document to the answer of a question in a result
+type Evidence struct {
+	models.BaseModel
+	ResultID    string `gorm:"column:result_id;type:uuid;not null;uniqueIndex:idx_result_evidence" json:"result_id"`
+	QuestionKey string `gorm:"column:question_key;not null;uniqueIndex:idx_result_evidence" json:"question_key"`
+	DocumentID  string `gorm:"column:document_id;type:uuid;not null;uniqueIndex:idx_result_evidence;index" json:"document_id"`
+}
+
+// TableName specifies the table name for the Evidence model
+func (Evidence) TableName() string {
+	return "result_evidence"
+}
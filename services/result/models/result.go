@@ -1,17 +1,55 @@
 package models
 
 import (
+	"time"
+
 	"security-questionnaire/pkg/models"
 )
 
 // Result represents a questionnaire result stored in the database
 type Result struct {
 	models.BaseModel
-	QuestionnaireID string `gorm:"column:questionnaire_id;not null;index" json:"questionnaire_id"`
-	Data            string `gorm:"column:data;type:jsonb" json:"data"`
-	Status          string `gorm:"column:status;not null;default:'pending'" json:"status"`
-	Score           *int   `gorm:"column:score" json:"score,omitempty"`
-	CompletedAt     *int64 `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	QuestionnaireID      string         `gorm:"column:questionnaire_id;not null;index" json:"questionnaire_id"`
+	QuestionnaireVersion int            `gorm:"column:questionnaire_version;not null;default:1" json:"questionnaire_version"`
+	Data                 models.JSONMap `gorm:"column:data;type:jsonb" json:"data"`
+	Status               Status         `gorm:"column:status;not null;default:'draft'" json:"status"`
+	StatusHistory        StatusHistory  `gorm:"column:status_history;type:jsonb" json:"status_history"`
+	Score                *int           `gorm:"column:score" json:"score,omitempty"`
+	SectionScores        models.JSONMap `gorm:"column:section_scores;type:jsonb" json:"section_scores,omitempty"`
+	CompletedAt          *int64         `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	// OwnerID is the subject of the vendor answering the questionnaire
+	OwnerID string `gorm:"column:owner_id;index" json:"owner_id,omitempty"`
+}
+
+// DefaultStatus is the status assigned to results created without one
+const DefaultStatus = StatusDraft
+
+// Transition moves the result to a new status, recording who did it, in
+// which role, and when. CompletedAt is set when the result is submitted and
+// cleared when it is reopened.
+func (r *Result) Transition(to Status, actor Actor, by string, at time.Time) error {
+	if err := CheckTransition(r.Status, to, actor); err != nil {
+		return err
+	}
+
+	r.StatusHistory = append(r.StatusHistory, StatusTransition{
+		From:  r.Status.normalize(),
+		To:    to,
+		Actor: actor,
+		By:    by,
+		At:    at,
+	})
+	r.Status = to
+
+	switch to {
+	case StatusSubmitted:
+		completedAt := at.Unix()
+		r.CompletedAt = &completedAt
+	case StatusInProgress:
+		r.CompletedAt = nil
+	}
+
+	return nil
 }
 
 // TableName specifies the table name for the Result model
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status is the lifecycle state of a result
+type Status string
+
+// Result lifecycle states
+const (
+	StatusDraft       Status = "draft"
+	StatusInProgress  Status = "in_progress"
+	StatusSubmitted   Status = "submitted"
+	StatusUnderReview Status = "under_review"
+	StatusApproved    Status = "approved"
+	StatusRejected    Status = "rejected"
+	StatusArchived    Status = "archived"
+
+	// statusPending is the legacy default status and behaves like StatusDraft.
+	// Migration 0009 rewrites it to StatusDraft.
+	statusPending Status = "pending"
+)
+
+// Actor identifies who triggers a status transition
+type Actor string
+
+// Transition actors
+const (
+	ActorRespondent Actor = "respondent"
+	ActorReviewer   Actor = "reviewer"
+	ActorAdmin      Actor = "admin"
+)
+
+// ParseActor converts a string into a known actor
+func ParseActor(value string) (Actor, error) {
+	switch actor := Actor(strings.ToLower(value)); actor {
+	case ActorRespondent, ActorReviewer, ActorAdmin:
+		return actor, nil
+	}
+	return "", fmt.Errorf("unknown actor %q", value)
+}
+
+// transitions lists the allowed target states and who may trigger them.
+// Admins may trigger every allowed transition.
+var transitions = map[Status]map[Status][]Actor{
+	StatusDraft: {
+		StatusInProgress: {ActorRespondent},
+		StatusSubmitted:  {ActorRespondent},
+	},
+	StatusInProgress: {
+		StatusSubmitted: {ActorRespondent},
+	},
+	StatusSubmitted: {
+		StatusUnderReview: {ActorReviewer},
+	},
+	StatusUnderReview: {
+		StatusApproved: {ActorReviewer},
+		StatusRejected: {ActorReviewer},
+	},
+	StatusApproved: {
+		StatusArchived: {ActorReviewer},
+	},
+	StatusRejected: {
+		StatusInProgress: {ActorRespondent},
+		StatusArchived:   {ActorReviewer},
+	},
+}
+
+// normalize maps legacy statuses onto the lifecycle
+func (s Status) normalize() Status {
+	if s == statusPending || s == "" {
+		return StatusDraft
+	}
+	return s
+}
+
+// Valid reports whether s is a lifecycle state
+func (s Status) Valid() bool {
+	switch s.normalize() {
+	case StatusDraft, StatusInProgress, StatusSubmitted, StatusUnderReview,
+		StatusApproved, StatusRejected, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// Initial reports whether a result may be created in state s
+func (s Status) Initial() bool {
+	switch s.normalize() {
+	case StatusDraft, StatusInProgress:
+		return true
+	}
+	return false
+}
+
+// Editable reports whether answers may still be changed in state s
+func (s Status) Editable() bool {
+	switch s.normalize() {
+	case StatusDraft, StatusInProgress:
+		return true
+	}
+	return false
+}
+
+// Next returns the states reachable from s
+func (s Status) Next() []Status {
+	var next []Status
+	for _, to := range statusOrder {
+		if _, ok := transitions[s.normalize()][to]; ok {
+			next = append(next, to)
+		}
+	}
+	return next
+}
+
+// statusOrder is the display order of lifecycle states
+var statusOrder = []Status{
+	StatusDraft, StatusInProgress, StatusSubmitted, StatusUnderReview,
+	StatusApproved, StatusRejected, StatusArchived,
+}
+
+// TransitionError describes a disallowed status change
+type TransitionError struct {
+	From  Status
+	To    Status
+	Actor Actor
+	// Allowed is true when the transition exists but the actor may not trigger it
+	Allowed bool
+}
+
+// Error implements the error interface
+func (e *TransitionError) Error() string {
+	if e.Allowed {
+		return fmt.Sprintf("%s may not move a result from %s to %s", e.Actor, e.From, e.To)
+	}
+
+	next := e.From.Next()
+	if len(next) == 0 {
+		return fmt.Sprintf("cannot move a result from %s to %s: %s is a final state", e.From, e.To, e.From)
+	}
+	names := make([]string, len(next))
+	for i, s := range next {
+		names[i] = string(s)
+	}
+	return fmt.Sprintf("cannot move a result from %s to %s: allowed next states are %s", e.From, e.To, strings.Join(names, ", "))
+}
+
+// CheckTransition verifies that actor may move a result from one state to another
+func CheckTransition(from, to Status, actor Actor) error {
+	from = from.normalize()
+	actors, ok := transitions[from][to]
+	if !ok {
+		return &TransitionError{From: from, To: to, Actor: actor}
+	}
+	if actor == ActorAdmin {
+		return nil
+	}
+	for _, a := range actors {
+		if a == actor {
+			return nil
+		}
+	}
+	return &TransitionError{From: from, To: to, Actor: actor, Allowed: true}
+}
+
+// StatusTransition records a single status change
+type StatusTransition struct {
+	From  Status `json:"from,omitempty"`
+	To    Status `json:"to"`
+	Actor Actor  `json:"actor"`
+	// By is the subject of the caller who made the change
+	By string    `json:"by,omitempty"`
+	At time.Time `json:"at"`
+}
+
+// StatusHistory is the ordered list of status changes stored in a jsonb column
+type StatusHistory []StatusTransition
+
+// Value implements driver.Valuer so the history is written as JSON
+func (h StatusHistory) Value() (driver.Value, error) {
+	if h == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status history: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner so jsonb values are read back into the history
+func (h *StatusHistory) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return fmt.Errorf("unsupported type for status history: %T", value)
+	}
+}